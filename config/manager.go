@@ -27,27 +27,26 @@ func NewManager(store Store) *Manager {
 	}
 }
 
-// Load reads data from storage.
+// Load reads the application configuration from storage.
+// It lives in its own section, so module sections written by the engine
+// (and persisted module state) are not affected by Load/Save.
 func (m *Manager) Load(v interface{}) error {
-	m.mu.Lock() // RLock -> Lock
-	defer m.mu.Unlock() // RUnlock -> Unlock
-
-	if m.store == nil {
-		return fmt.Errorf("config: no storage driver")
-	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	data, err := m.store.Load()
+	sections, err := m.loadSections()
 	if err != nil {
 		return err
 	}
 
-	if len(data) == 0 {
+	data, ok := sections[appSection]
+	if !ok || len(data) == 0 {
 		return ErrNoConfig
 	}
 
 	if err := cbor.Unmarshal(data, v); err != nil {
 		logger.Error("Config: Corrupt data found. Decode failed: %v", err)
-		return fmt.Errorf("config decode failed: %w", err)
+		return fmt.Errorf("%w: decode failed: %w", ErrCorrupt, err)
 	}
 
 	if validator, ok := v.(Validator); ok {
@@ -61,26 +60,22 @@ func (m *Manager) Load(v interface{}) error {
 	return nil
 }
 
-// Save persists configuration.
+// Save persists the application configuration, keeping all other sections intact.
 func (m *Manager) Save(v interface{}) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.store == nil {
-		return fmt.Errorf("config: no storage driver")
-	}
-
 	data, err := cbor.Marshal(v)
 	if err != nil {
 		return fmt.Errorf("config encode failed: %w", err)
 	}
 
-	if err := m.store.Save(data); err != nil {
-		logger.Error("Config: Write failed: %v", err)
+	total, err := m.updateSection(appSection, data)
+	if err != nil {
 		return err
 	}
 
-	logger.Info("Config saved (%d bytes)", len(data))
+	logger.Info("Config saved (%d bytes)", total)
 	return nil
 }
 
@@ -92,7 +87,7 @@ func (m *Manager) Reset() error {
 	if m.store == nil {
 		return nil
 	}
-	
+
 	logger.Warn("Config: Performing factory reset")
 	return m.store.Clear()
-}
\ No newline at end of file
+}
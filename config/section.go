@@ -0,0 +1,176 @@
+// config/section.go
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/magradze/gonnect/pkg/cbor"
+	"github.com/magradze/gonnect/pkg/logger"
+)
+
+// Sections split the stored blob into independently typed parts, keyed by name
+// (e.g., one per module). The blob is a tagged CBOR map of name -> raw CBOR value.
+// The application configuration used by Load/Save is the reserved section "",
+// so saving it never touches module sections and vice versa.
+//
+// A blob without the tag was written by an older firmware as a plain
+// application config; it is read as the "" section and converted on the next write.
+
+// sectionsTag marks a sectioned blob (CBOR tag, from the unassigned range).
+const sectionsTag = 26478
+
+// appSection is the reserved section holding the Load/Save configuration.
+const appSection = ""
+
+// ErrReservedSection is returned when a section name is reserved.
+var ErrReservedSection = errors.New("config: reserved section name")
+
+// LoadSection decodes the named section into v and validates it.
+// It returns ErrNoConfig if the store is empty or the section is missing,
+// in which case v is left untouched (keeping any defaults).
+func (m *Manager) LoadSection(name string, v interface{}) error {
+	if name == appSection {
+		return ErrReservedSection
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sections, err := m.loadSections()
+	if err != nil {
+		return err
+	}
+
+	raw, ok := sections[name]
+	if !ok {
+		return ErrNoConfig
+	}
+
+	if err := cbor.Unmarshal(raw, v); err != nil {
+		logger.Error("Config: Corrupt section '%s'. Decode failed: %v", name, err)
+		return fmt.Errorf("%w: section '%s' decode failed: %w", ErrCorrupt, name, err)
+	}
+
+	if validator, ok := v.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			logger.Error("Config: Validation of section '%s' failed: %v", name, err)
+			return fmt.Errorf("config section '%s' validation failed: %w", name, err)
+		}
+	}
+
+	logger.Debug("Config section '%s' loaded (%d bytes)", name, len(raw))
+	return nil
+}
+
+// SaveSection encodes v into the named section, keeping all other sections intact.
+func (m *Manager) SaveSection(name string, v interface{}) error {
+	if name == appSection {
+		return ErrReservedSection
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	raw, err := cbor.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("config section '%s' encode failed: %w", name, err)
+	}
+
+	total, err := m.updateSection(name, raw)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("Config section '%s' saved (%d bytes total)", name, total)
+	return nil
+}
+
+// DeleteSection removes the named section, keeping all other sections intact.
+// Deleting a missing section is not an error and does not write to the store.
+func (m *Manager) DeleteSection(name string) error {
+	if name == appSection {
+		return ErrReservedSection
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sections, err := m.loadSections()
+	if errors.Is(err, ErrNoConfig) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if _, ok := sections[name]; !ok {
+		return nil
+	}
+	delete(sections, name)
+
+	if _, err := m.writeSections(sections); err != nil {
+		return err
+	}
+	logger.Debug("Config section '%s' deleted", name)
+	return nil
+}
+
+// updateSection replaces one section and writes the blob back.
+// A corrupt blob is replaced, so that saving defaults recovers from it.
+// It returns the size of the written blob. The caller must hold m.mu.
+func (m *Manager) updateSection(name string, raw cbor.RawMessage) (int, error) {
+	sections, err := m.loadSections()
+	switch {
+	case errors.Is(err, ErrCorrupt):
+		logger.Warn("Config: Discarding corrupt data while saving section '%s'", name)
+		fallthrough
+	case errors.Is(err, ErrNoConfig):
+		sections = make(map[string]cbor.RawMessage, 1)
+	case err != nil:
+		return 0, err
+	}
+	sections[name] = raw
+	return m.writeSections(sections)
+}
+
+// writeSections encodes the sections and saves them as the new blob.
+// It returns the size of the written blob. The caller must hold m.mu.
+func (m *Manager) writeSections(sections map[string]cbor.RawMessage) (int, error) {
+	data, err := cbor.Marshal(cbor.Tag{Number: sectionsTag, Content: sections})
+	if err != nil {
+		return 0, fmt.Errorf("config encode failed: %w", err)
+	}
+
+	if err := m.store.Save(data); err != nil {
+		logger.Error("Config: Write failed: %v", err)
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// loadSections reads and splits the stored blob. The caller must hold m.mu.
+func (m *Manager) loadSections() (map[string]cbor.RawMessage, error) {
+	if m.store == nil {
+		return nil, fmt.Errorf("config: no storage driver")
+	}
+
+	data, err := m.store.Load()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoConfig
+	}
+
+	var tagged cbor.RawTag
+	if err := cbor.Unmarshal(data, &tagged); err != nil || tagged.Number != sectionsTag {
+		// Legacy blob: the whole store is the application config.
+		return map[string]cbor.RawMessage{appSection: data}, nil
+	}
+
+	var sections map[string]cbor.RawMessage
+	if err := cbor.Unmarshal(tagged.Content, &sections); err != nil {
+		logger.Error("Config: Corrupt data found. Decode failed: %v", err)
+		return nil, fmt.Errorf("%w: decode failed: %w", ErrCorrupt, err)
+	}
+	return sections, nil
+}
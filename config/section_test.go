@@ -0,0 +1,116 @@
+// config/section_test.go
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/magradze/gonnect/pkg/cbor"
+)
+
+// memStore keeps the blob in memory.
+type memStore struct{ data []byte }
+
+func (s *memStore) Load() ([]byte, error) {
+	if s.data == nil {
+		return nil, ErrNoConfig
+	}
+	return s.data, nil
+}
+func (s *memStore) Save(data []byte) error { s.data = append([]byte(nil), data...); return nil }
+func (s *memStore) Clear() error           { s.data = nil; return nil }
+
+type appConfig struct{ SSID string }
+type pinConfig struct{ Pin uint8 }
+
+func TestSaveKeepsSections(t *testing.T) {
+	m := NewManager(&memStore{})
+
+	if err := m.SaveSection("led", &pinConfig{Pin: 13}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Save(&appConfig{SSID: "home"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SaveSection("button", &pinConfig{Pin: 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	var pin pinConfig
+	if err := m.LoadSection("led", &pin); err != nil || pin.Pin != 13 {
+		t.Fatalf("LoadSection(led) = %+v, %v", pin, err)
+	}
+	var app appConfig
+	if err := m.Load(&app); err != nil || app.SSID != "home" {
+		t.Fatalf("Load = %+v, %v", app, err)
+	}
+}
+
+func TestLegacyBlob(t *testing.T) {
+	for _, v := range []interface{}{&appConfig{SSID: "old"}, 42} {
+		data, err := cbor.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := NewManager(&memStore{data: data})
+
+		var pin pinConfig
+		if err := m.LoadSection("led", &pin); !errors.Is(err, ErrNoConfig) {
+			t.Fatalf("LoadSection on a legacy blob = %v, want ErrNoConfig", err)
+		}
+		if err := m.SaveSection("led", &pinConfig{Pin: 2}); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := v.(*appConfig); ok {
+			var app appConfig
+			if err := m.Load(&app); err != nil || app.SSID != "old" {
+				t.Fatalf("legacy app config lost: %+v, %v", app, err)
+			}
+		}
+	}
+}
+
+func TestReservedSection(t *testing.T) {
+	m := NewManager(&memStore{})
+	if err := m.SaveSection(appSection, &pinConfig{}); !errors.Is(err, ErrReservedSection) {
+		t.Fatalf("SaveSection(\"\") = %v", err)
+	}
+}
+
+func TestSaveRecoversCorruptBlob(t *testing.T) {
+	// A sectioned blob whose content is not a map of sections.
+	data, err := cbor.Marshal(cbor.Tag{Number: sectionsTag, Content: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(&memStore{data: data})
+
+	var app appConfig
+	if err := m.Load(&app); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("Load = %v, want ErrCorrupt", err)
+	}
+	if err := m.Save(&appConfig{SSID: "default"}); err != nil {
+		t.Fatalf("Save over a corrupt blob = %v", err)
+	}
+	if err := m.Load(&app); err != nil || app.SSID != "default" {
+		t.Fatalf("Load after recovery = %+v, %v", app, err)
+	}
+}
+
+func TestCorruptSection(t *testing.T) {
+	m := NewManager(&memStore{})
+	if err := m.SaveSection("led", "not a struct"); err != nil {
+		t.Fatal(err)
+	}
+
+	var pin pinConfig
+	if err := m.LoadSection("led", &pin); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("LoadSection = %v, want ErrCorrupt", err)
+	}
+	if err := m.DeleteSection("led"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.LoadSection("led", &pin); !errors.Is(err, ErrNoConfig) {
+		t.Fatalf("LoadSection after delete = %v, want ErrNoConfig", err)
+	}
+}
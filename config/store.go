@@ -3,8 +3,12 @@ package config
 
 import "errors"
 
-// ErrNoConfig is returned when the store is empty, uninitialized, or the key is missing.
-var ErrNoConfig = errors.New("config: not found")
+var (
+	// ErrNoConfig is returned when the store is empty, uninitialized, or the key is missing.
+	ErrNoConfig = errors.New("config: not found")
+	// ErrCorrupt is returned when stored data cannot be decoded.
+	ErrCorrupt = errors.New("config: corrupt data")
+)
 
 // Store defines the persistence layer for configuration data.
 // It abstracts the underlying storage mechanism (NVS, EEPROM, LittleFS, SD Card).
@@ -23,4 +27,4 @@ type Store interface {
 	// Clear removes the configuration data (Factory Reset).
 	// This should physically erase the data or invalidate the key.
 	Clear() error
-}
\ No newline at end of file
+}
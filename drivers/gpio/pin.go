@@ -13,6 +13,8 @@ type Pin struct {
 	hw    machine.Pin
 	id    resource.ID
 	owner string
+	res   *resource.Manager
+	irq   bool
 }
 
 // New claims a GPIO pin, locks it, and configures the hardware mode.
@@ -22,12 +24,18 @@ type Pin struct {
 //
 //	led, err := gpio.New(machine.LED, machine.PinOutput, "status_led")
 func New(pin machine.Pin, mode machine.PinMode, owner string) (*Pin, error) {
+	return NewWith(resource.Default(), pin, mode, owner)
+}
+
+// NewWith is like New but locks the pin in a specific resource manager,
+// typically the one from the module's engine Runtime.
+func NewWith(res *resource.Manager, pin machine.Pin, mode machine.PinMode, owner string) (*Pin, error) {
 	// Cast machine.Pin to our internal uint16 ID type.
 	// This works across all TinyGo supported architectures (AVR, ARM, RISC-V).
 	resID := resource.ID(uint16(pin))
 
 	// 1. Acquire Lock
-	if err := resource.Lock(resource.GPIO, resID, owner); err != nil {
+	if err := res.Lock(resource.GPIO, resID, owner); err != nil {
 		return nil, err
 	}
 
@@ -39,6 +47,7 @@ func New(pin machine.Pin, mode machine.PinMode, owner string) (*Pin, error) {
 		hw:    pin,
 		id:    resID,
 		owner: owner,
+		res:   res,
 	}, nil
 }
 
@@ -69,8 +78,28 @@ func (p *Pin) Toggle() {
 	p.hw.Set(!p.hw.Get())
 }
 
-// Close releases the resource lock.
+// SetInterrupt installs a pin-change interrupt handler.
+// The callback runs in interrupt context: it must not lock, allocate or block,
+// so use it to feed an event.ISRSource rather than calling event.Publish.
+//
+// Usage:
+//
+//	src := event.NewISRSource("button", 8, "input/button/press")
+//	btn.SetInterrupt(machine.PinFalling, func(machine.Pin) { src.Publish(0, 1) })
+func (p *Pin) SetInterrupt(change machine.PinChange, callback func(machine.Pin)) error {
+	if err := p.hw.SetInterrupt(change, callback); err != nil {
+		return err
+	}
+	p.irq = callback != nil
+	return nil
+}
+
+// Close releases the resource lock and removes any interrupt handler.
 // The pin hardware state remains unchanged (it does not automatically reset to input).
 func (p *Pin) Close() error {
-	return resource.Unlock(resource.GPIO, p.id, p.owner)
-}
\ No newline at end of file
+	if p.irq {
+		p.hw.SetInterrupt(0, nil)
+		p.irq = false
+	}
+	return p.res.Unlock(resource.GPIO, p.id, p.owner)
+}
@@ -0,0 +1,40 @@
+// drivers/watchdog/watchdog.go
+package watchdog
+
+import (
+	"machine"
+	"time"
+)
+
+// Hardware adapts machine.Watchdog to the engine.Watchdog interface.
+//
+// The engine stops feeding it when Run returns. Code that keeps the device busy
+// after Shutdown, such as an OTA update, must feed it itself:
+//
+//	app := engine.New(nil)
+//	app.Watchdog = watchdog.Hardware{}
+//	...
+//	app.Shutdown()
+//	<-app.Done()
+//	for chunk := range firmware {
+//		app.Watchdog.Feed()
+//		write(chunk)
+//	}
+type Hardware struct{}
+
+// Start configures and arms the hardware watchdog.
+// Note: On most MCUs the watchdog cannot be disabled once started.
+func (Hardware) Start(timeout time.Duration) error {
+	err := machine.Watchdog.Configure(machine.WatchdogConfig{
+		TimeoutMillis: uint32(timeout / time.Millisecond),
+	})
+	if err != nil {
+		return err
+	}
+	return machine.Watchdog.Start()
+}
+
+// Feed restarts the hardware countdown.
+func (Hardware) Feed() {
+	machine.Watchdog.Update()
+}
@@ -0,0 +1,56 @@
+// engine/configure.go
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/magradze/gonnect"
+	"github.com/magradze/gonnect/config"
+	"github.com/magradze/gonnect/pkg/cbor"
+)
+
+// configure loads a gonnect.Configurable module's section into its config struct.
+// A missing store, section or a corrupt section keeps the defaults; they are
+// still validated.
+func (e *Engine) configure(m gonnect.Module) error {
+	c, ok := m.(gonnect.Configurable)
+	if !ok {
+		return nil
+	}
+	cfg := c.Config()
+	if cfg == nil {
+		return nil
+	}
+
+	if e.Config != nil {
+		// A failed decode may leave cfg half written; keep the defaults to fall back on.
+		defaults, _ := cbor.Marshal(cfg)
+
+		err := e.Config.LoadSection(m.Name(), cfg)
+		switch {
+		case err == nil:
+			e.log.Debug("Loaded config for module '%s'", m.Name())
+			return nil
+		case errors.Is(err, config.ErrCorrupt):
+			// A damaged flash sector must not turn into a boot failure.
+			e.log.Warn("Ignoring corrupt config of module '%s': %v", m.Name(), err)
+			if defaults != nil {
+				if err := cbor.Unmarshal(defaults, cfg); err != nil {
+					return fmt.Errorf("default config restore failed: %w", err)
+				}
+			}
+		case !errors.Is(err, config.ErrNoConfig):
+			return err
+		}
+	}
+
+	// LoadSection validates what it decodes; defaults need the same check.
+	if v, ok := cfg.(config.Validator); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("default config validation failed: %w", err)
+		}
+	}
+	e.log.Debug("Using default config for module '%s'", m.Name())
+	return nil
+}
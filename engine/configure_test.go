@@ -0,0 +1,46 @@
+// engine/configure_test.go
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magradze/gonnect/config"
+)
+
+type ledConfig struct {
+	Pin  uint8
+	Name string
+}
+
+// configModule is a testModule with a config section. Modules are critical by
+// default, so a config error would make the boot fatal.
+type configModule struct {
+	testModule
+	cfg ledConfig
+}
+
+func (m *configModule) Config() any { return &m.cfg }
+
+func TestConfigureCorruptSectionUsesDefaults(t *testing.T) {
+	store := &memStore{}
+	// A section of the wrong shape: decoding into ledConfig fails.
+	if err := config.NewManager(store).SaveSection("led", []string{"x", "y"}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &configModule{testModule: testModule{name: "led"}, cfg: ledConfig{Pin: 13, Name: "status"}}
+	rt := NewRuntime()
+	rt.Log = quietLogger{}
+	rt.Registry.RegisterModule(m)
+	e := NewWithRuntime(store, rt)
+
+	errCh := start(t, e, context.Background())
+	e.Shutdown()
+	if err := <-errCh; err != nil {
+		t.Fatalf("RunContext = %v, want a clean boot on defaults", err)
+	}
+	if m.cfg != (ledConfig{Pin: 13, Name: "status"}) {
+		t.Fatalf("config = %+v, want the defaults", m.cfg)
+	}
+}
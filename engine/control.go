@@ -0,0 +1,196 @@
+// engine/control.go
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/magradze/gonnect"
+)
+
+var (
+	// ErrNotRunning is returned by module control calls made outside of Run.
+	ErrNotRunning = errors.New("engine: not running")
+	// ErrUnknownModule is returned when no module with the given name is registered.
+	ErrUnknownModule = errors.New("engine: unknown module")
+	// ErrModuleActive is returned by StartModule when the module is already running.
+	ErrModuleActive = errors.New("engine: module already active")
+	// ErrDependencyInactive is returned by StartModule when a dependency is not running.
+	ErrDependencyInactive = errors.New("engine: dependency not active")
+	// ErrStopOverrun is returned by StopModule and RestartModule when a module did not
+	// leave Start or return from Stop within its shutdown budget.
+	ErrStopOverrun = errors.New("engine: module did not stop in time")
+	// ErrModuleBusy is returned by StartModule while an overrunning Start or Stop
+	// of a previous run is still executing.
+	ErrModuleBusy = errors.New("engine: module still stopping")
+)
+
+// StopModule stops a single module at runtime: it cancels the module's context,
+// waits for Start to return, calls Stop and releases its resources.
+// Active modules that depend on it (directly or transitively) are stopped first.
+// If any of them overran its shutdown budget, an ErrStopOverrun error is returned
+// and the module cannot be started again until its goroutines have returned.
+//
+// Do not call it from the module's own Start goroutine; the join would overrun.
+func (e *Engine) StopModule(name string) error {
+	e.opMu.Lock()
+	defer e.opMu.Unlock()
+
+	_, err := e.stopWithDependents(name)
+	return err
+}
+
+// StartModule re-runs Init and launches Start for a stopped or failed module.
+// All of its dependencies must be active.
+func (e *Engine) StartModule(name string) error {
+	e.opMu.Lock()
+	defer e.opMu.Unlock()
+
+	u, err := e.lookup(name)
+	if err != nil {
+		return err
+	}
+	return e.startUnit(u)
+}
+
+// RestartModule stops a module together with its active dependents, then
+// starts it again and relaunches the dependents in boot order.
+// Nothing is restarted if one of them overran its shutdown budget.
+func (e *Engine) RestartModule(name string) error {
+	e.opMu.Lock()
+	defer e.opMu.Unlock()
+
+	stopped, err := e.stopWithDependents(name)
+	if err != nil {
+		return err
+	}
+
+	// The target is (re)started even if it was not active, e.g. after a failed Init.
+	target, err := e.lookup(name)
+	if err != nil {
+		return err
+	}
+	if err := e.startUnit(target); err != nil {
+		return err
+	}
+
+	// stopped is in reverse boot order; relaunch the dependents front to back.
+	for i := len(stopped) - 1; i >= 0; i-- {
+		u := stopped[i]
+		if u == target {
+			continue
+		}
+		if err := e.startUnit(u); err != nil {
+			return fmt.Errorf("engine: restart of '%s' failed at dependent '%s': %w", name, u.mod.Name(), err)
+		}
+	}
+	return nil
+}
+
+// lookup finds a unit by name. It fails if Run has not reached the startup phase.
+func (e *Engine) lookup(name string) (*unit, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.ctx == nil || e.ctx.Err() != nil {
+		return nil, ErrNotRunning
+	}
+	for _, u := range e.units {
+		if u.mod.Name() == name {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: '%s'", ErrUnknownModule, name)
+}
+
+// stopWithDependents stops the named module and every active module depending on it.
+// It returns the units it stopped in the order they were stopped, and an
+// ErrStopOverrun error naming the first module that overran.
+func (e *Engine) stopWithDependents(name string) ([]*unit, error) {
+	target, err := e.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// e.units is topologically sorted, so every dependent appears after the target.
+	// A single forward pass collects the transitive closure.
+	e.mu.Lock()
+	affected := map[string]bool{name: true}
+	chain := []*unit{target}
+	seen := false
+	for _, u := range e.units {
+		if u == target {
+			seen = true
+			continue
+		}
+		if !seen {
+			continue
+		}
+		if d, ok := u.mod.(gonnect.Dependent); ok {
+			for _, dep := range d.DependsOn() {
+				if affected[dep] {
+					affected[u.mod.Name()] = true
+					chain = append(chain, u)
+					break
+				}
+			}
+		}
+	}
+	e.mu.Unlock()
+
+	stopped := make([]*unit, 0, len(chain))
+	var overrun *Overrun
+	for i := len(chain) - 1; i >= 0; i-- {
+		u := chain[i]
+		if !e.isActive(u) {
+			continue
+		}
+		if u != target {
+			e.log.Info("Stopping dependent module '%s' (requires '%s')", u.mod.Name(), name)
+		}
+		if o := e.stopUnit(u); len(o) > 0 && overrun == nil {
+			overrun = &o[0]
+		}
+		stopped = append(stopped, u)
+	}
+	if overrun != nil {
+		return stopped, fmt.Errorf("%w: '%s' overran its %s budget", ErrStopOverrun, overrun.Module, overrun.Phase)
+	}
+	return stopped, nil
+}
+
+// startUnit re-initializes and launches a single unit.
+func (e *Engine) startUnit(u *unit) error {
+	if e.isActive(u) {
+		return fmt.Errorf("%w: '%s'", ErrModuleActive, u.mod.Name())
+	}
+	if e.isBusy(u) {
+		return fmt.Errorf("%w: '%s'", ErrModuleBusy, u.mod.Name())
+	}
+
+	if d, ok := u.mod.(gonnect.Dependent); ok {
+		for _, dep := range d.DependsOn() {
+			du, err := e.lookup(dep)
+			if err != nil {
+				return err
+			}
+			if !e.isActive(du) {
+				return fmt.Errorf("%w: '%s' requires '%s'", ErrDependencyInactive, u.mod.Name(), dep)
+			}
+		}
+	}
+
+	if err := e.initUnit(u); err != nil {
+		e.log.Error("Failed to re-initialize module '%s': %v", u.mod.Name(), err)
+		return err
+	}
+	// An explicit start grants a fresh restart budget; relaunches after a
+	// suspend keep the old one.
+	e.mu.Lock()
+	u.history = u.history[:0]
+	e.mu.Unlock()
+
+	e.log.Info("Starting module '%s'", u.mod.Name())
+	e.launch(u)
+	return nil
+}
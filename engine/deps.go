@@ -0,0 +1,88 @@
+// engine/deps.go
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/magradze/gonnect"
+)
+
+var (
+	// ErrMissingDependency is returned when a module depends on a name that is not registered.
+	ErrMissingDependency = errors.New("engine: missing dependency")
+	// ErrDependencyCycle is returned when module dependencies form a loop.
+	ErrDependencyCycle = errors.New("engine: dependency cycle")
+)
+
+// Visit states for the depth-first traversal.
+const (
+	unvisited uint8 = iota
+	visiting
+	visited
+)
+
+// sortModules orders modules so that every dependency precedes its dependents.
+// Modules without dependency constraints keep their registration order, so the
+// result is deterministic for a given set of imports.
+func sortModules(modules []gonnect.Module) ([]gonnect.Module, error) {
+	// Index by name. N is small (<50), but a map keeps the traversal linear.
+	index := make(map[string]int, len(modules))
+	for i, m := range modules {
+		index[m.Name()] = i
+	}
+
+	state := make([]uint8, len(modules))
+	sorted := make([]gonnect.Module, 0, len(modules))
+	// path holds the current traversal chain for cycle reporting.
+	path := make([]string, 0, len(modules))
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		m := modules[i]
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			// Trim the path to the start of the loop: "a -> b -> c -> a".
+			start := 0
+			for j, name := range path {
+				if name == m.Name() {
+					start = j
+					break
+				}
+			}
+			loop := append(path[start:len(path):len(path)], m.Name())
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(loop, " -> "))
+		}
+
+		state[i] = visiting
+		path = append(path, m.Name())
+
+		if d, ok := m.(gonnect.Dependent); ok {
+			for _, dep := range d.DependsOn() {
+				j, exists := index[dep]
+				if !exists {
+					return fmt.Errorf("%w: module '%s' requires '%s'", ErrMissingDependency, m.Name(), dep)
+				}
+				if err := visit(j); err != nil {
+					return err
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[i] = visited
+		sorted = append(sorted, m)
+		return nil
+	}
+
+	for i := range modules {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
@@ -0,0 +1,108 @@
+// engine/deps_test.go
+package engine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/magradze/gonnect"
+)
+
+func names(mods []gonnect.Module) string {
+	s := make([]string, len(mods))
+	for i, m := range mods {
+		s[i] = m.Name()
+	}
+	return strings.Join(s, ",")
+}
+
+func TestSortModulesOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		mods []gonnect.Module
+		want string
+	}{
+		{
+			name: "registration order without deps",
+			mods: []gonnect.Module{&testModule{name: "c"}, &testModule{name: "a"}, &testModule{name: "b"}},
+			want: "c,a,b",
+		},
+		{
+			name: "dependency moves ahead",
+			mods: []gonnect.Module{
+				&testModule{name: "app", deps: []string{"net"}},
+				&testModule{name: "led"},
+				&testModule{name: "net"},
+			},
+			want: "net,app,led",
+		},
+		{
+			name: "transitive and shared deps",
+			mods: []gonnect.Module{
+				&testModule{name: "mqtt", deps: []string{"wifi", "clock"}},
+				&testModule{name: "wifi", deps: []string{"clock"}},
+				&testModule{name: "clock"},
+				&testModule{name: "ui"},
+			},
+			want: "clock,wifi,mqtt,ui",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := sortModules(tt.mods)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := names(sorted); got != tt.want {
+				t.Fatalf("order = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortModulesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mods    []gonnect.Module
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:    "missing dependency",
+			mods:    []gonnect.Module{&testModule{name: "app", deps: []string{"net"}}},
+			wantErr: ErrMissingDependency,
+			wantMsg: "module 'app' requires 'net'",
+		},
+		{
+			name:    "self dependency",
+			mods:    []gonnect.Module{&testModule{name: "a", deps: []string{"a"}}},
+			wantErr: ErrDependencyCycle,
+			wantMsg: "a -> a",
+		},
+		{
+			name: "cycle reports only the loop",
+			mods: []gonnect.Module{
+				&testModule{name: "root", deps: []string{"a"}},
+				&testModule{name: "a", deps: []string{"b"}},
+				&testModule{name: "b", deps: []string{"c"}},
+				&testModule{name: "c", deps: []string{"a"}},
+			},
+			wantErr: ErrDependencyCycle,
+			wantMsg: "a -> b -> c -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sortModules(tt.mods)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.HasSuffix(err.Error(), tt.wantMsg) {
+				t.Fatalf("err = %q, want suffix %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -3,26 +3,97 @@ package engine
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	// დავამატეთ მთავარი პაკეტის იმპორტი
 	"github.com/magradze/gonnect"
 	"github.com/magradze/gonnect/config"
 	"github.com/magradze/gonnect/pkg/logger"
-	"github.com/magradze/gonnect/registry"
 )
 
 // Engine is the central orchestrator of the framework.
-// It manages the bootstrap process, module lifecycle, and configuration injection.
+// It manages the bootstrap process, module lifecycle, and configuration injection
+// (see gonnect.Configurable).
 type Engine struct {
-	Config     *config.Manager
+	Config *config.Manager
+
+	// DefaultRestartPolicy applies to modules that do not implement gonnect.Supervised.
+	// The zero value keeps crashed modules stopped.
+	DefaultRestartPolicy gonnect.RestartPolicy
+
+	// RebootHook is invoked when a module escalates with gonnect.EscalateReboot.
+	// Typically wired to machine.CPUReset(). If nil, the engine shuts down instead.
+	RebootHook func()
+
+	// ShutdownTimeout is the default per-module budget for leaving Start and returning
+	// from Stop. Zero means DefaultShutdownTimeout.
+	ShutdownTimeout time.Duration
+
+	// Watchdog, if set, is armed when the system is running and fed only while
+	// every gonnect.Watched module keeps calling Heartbeat(ctx).
+	// Feeding stops when RunContext returns, but a hardware watchdog cannot be
+	// disarmed: code that keeps running after Shutdown (an OTA download, deep-sleep
+	// preparation) must call Watchdog.Feed itself or the device resets.
+	Watchdog Watchdog
+	// WatchdogTimeout is the hardware timeout. Zero means DefaultWatchdogTimeout.
+	WatchdogTimeout time.Duration
+
+	// Sleep is the low-power backend used by Suspend. If nil, Suspend fails.
+	Sleep SleepBackend
+
+	rt  *Runtime
+	log logger.Logger
+
 	shutdownCh chan struct{}
+	doneCh     chan struct{}
+	stopOnce   sync.Once
+
+	// opMu serializes lifecycle operations (module control and shutdown).
+	opMu sync.Mutex
+
+	mu      sync.Mutex
+	started bool
+	exit    *ExitError
+	wake    gonnect.WakeCause
+	// suspender is the unit blocked in Suspend, if any.
+	suspender *unit
+
+	bootStart time.Time
+	boot      BootReport
+	ctx       context.Context // root context for module goroutines, set by Run
+	units     []*unit         // all modules in boot order, including failed ones
+	failures  []InitFailure
+	report    ShutdownReport
 }
 
-// New creates a new Engine instance.
+// New creates a new Engine instance bound to the package-level defaults
+// (modules registered via registry.RegisterModule, event.Publish, resource.Lock).
 // 'store' is optional; pass nil if persistence is not required.
 func New(store config.Store) *Engine {
+	return NewWithRuntime(store, DefaultRuntime())
+}
+
+// NewWithRuntime creates an Engine that uses an isolated Runtime.
+// Nil fields of 'rt' fall back to the package-level defaults.
+//
+// Usage:
+//
+//	rt := engine.NewRuntime()
+//	rt.Registry.RegisterModule(&sensor.Module{})
+//	app := engine.NewWithRuntime(nil, rt)
+func NewWithRuntime(store config.Store, rt *Runtime) *Engine {
+	if rt == nil {
+		rt = DefaultRuntime()
+	}
+	rt = rt.withDefaults()
+
 	e := &Engine{
+		rt:         rt,
+		log:        rt.Log,
 		shutdownCh: make(chan struct{}),
+		doneCh:     make(chan struct{}),
 	}
 	if store != nil {
 		e.Config = config.NewManager(store)
@@ -30,77 +101,272 @@ func New(store config.Store) *Engine {
 	return e
 }
 
-// Run executes the main application loop.
-// 1. It initializes all registered modules via Init().
-// 2. It starts all modules via Start() in separate goroutines.
-// 3. It blocks indefinitely until Shutdown() is called.
-// 4. It triggers Stop() on all modules for graceful shutdown.
+// Runtime returns the services scoped to this Engine.
+func (e *Engine) Runtime() *Runtime {
+	return e.rt
+}
+
+// Run executes the main application loop in the background context.
+// It keeps the historical bare-metal behaviour: a fatal boot error panics,
+// so that a configured Watchdog Timer (WDT) resets the device.
+// Hosts that need the exit reason should use RunContext instead.
 func (e *Engine) Run() {
-	logger.Info("Gonnect Engine starting...")
+	var xe *ExitError
+	if err := e.RunContext(context.Background()); errors.As(err, &xe) && xe.Reason == ExitFatal {
+		panic(xe.Err)
+	}
+}
+
+// RunContext executes the main application loop.
+// 0. It orders modules so that dependencies (gonnect.Dependent) boot first.
+// 1. It initializes all registered modules via Init().
+// 2. It starts all modules via Start() in separate supervised goroutines.
+// 3. It blocks until Shutdown() is called, the parent ctx is cancelled,
+// or a supervised module escalates.
+// 4. It waits for every Start() to return and triggers Stop() in reverse boot order,
+// bounded by the per-module shutdown timeout.
+//
+// It returns nil after Shutdown(), and an *ExitError describing the cause otherwise.
+func (e *Engine) RunContext(parent context.Context) error {
+	e.mu.Lock()
+	started := e.started
+	e.started = true
+	e.mu.Unlock()
+	if started {
+		return ErrAlreadyStarted
+	}
+
+	defer close(e.doneCh)
+	e.log.Info("Gonnect Engine starting...")
+
+	bootStart := time.Now()
+	e.mu.Lock()
+	e.bootStart = bootStart
+	e.boot.HeapBefore = heapAlloc()
+	e.mu.Unlock()
+
+	e.log.Debug("Found %d registered modules", len(e.rt.Registry.GetModules()))
 
-	modules := registry.GetModules()
-	logger.Debug("Found %d registered modules", len(modules))
+	// --- Phase 0: Dependency Resolution ---
+	// A missing or cyclic dependency is a wiring bug, not a runtime condition.
+	// Failing here gives a clear message instead of a nil service lookup later.
+	modules, err := sortModules(e.rt.Registry.GetModules())
+	if err != nil {
+		e.log.Error("FATAL: Failed to resolve module dependencies: %v", err)
+		e.setExit(ExitFatal, "", err)
+		return e.exitErr()
+	}
+
+	all := make([]*unit, len(modules))
+	for i, m := range modules {
+		all[i] = newUnit(m, e.DefaultRestartPolicy)
+	}
+	e.mu.Lock()
+	e.units = all
+	e.mu.Unlock()
+	e.rt.Bus.Publish(TopicEngineStarting, int64(len(all)), nil, Source)
+
+	// A backend that resets the CPU on wake-up reports the cause of this boot.
+	if r, ok := e.Sleep.(BootWakeReporter); ok {
+		e.mu.Lock()
+		e.wake = r.BootWakeCause()
+		e.mu.Unlock()
+	}
 
 	// --- Phase 1: Initialization ---
-	// Init is synchronous. If any module fails to initialize, the system halts.
+	// Init is synchronous. If a critical module fails to initialize, the system halts.
 	// This ensures we don't start with a broken state (e.g., failed hardware lock).
-	for _, m := range modules {
-		logger.Debug("Initializing module: %s", m.Name())
-		if err := m.Init(); err != nil {
-			logger.Error("FATAL: Failed to initialize module '%s': %v", m.Name(), err)
-			// In embedded systems, failing Init is usually unrecoverable.
-			// Panicking here is the correct behavior to trigger a Watchdog Timer (WDT) reset if configured.
-			panic(err)
-		}
+	// Optional modules are skipped instead, and the system boots in degraded mode.
+	units, err := e.initModules(all)
+	if err != nil {
+		return err
+	}
+	if e.Degraded() {
+		e.log.Warn("System booting in degraded mode (%d modules skipped)", len(e.Failures()))
+	} else {
+		e.log.Info("All modules initialized successfully")
 	}
-	logger.Info("All modules initialized successfully")
 
 	// --- Phase 2: Startup ---
 	// Create a root context that we can cancel upon shutdown.
-	ctx, cancel := context.WithCancel(context.Background())
+	// The Runtime travels in the context so modules can reach it via RuntimeFrom(ctx).
+	ctx, cancel := context.WithCancel(context.WithValue(parent, runtimeKey{}, e.rt))
 	defer cancel()
+	e.mu.Lock()
+	e.ctx = ctx
+	e.mu.Unlock()
 
-	for _, m := range modules {
+	for _, u := range units {
 		// Launch each module in its own goroutine.
-		// We wrap the call to handle panics and ensure the loop variable 'm' is captured correctly.
-		
-		// FIX: registry.Module -> gonnect.Module
-		go func(mod gonnect.Module) {
-			defer func() {
-				if r := recover(); r != nil {
-					logger.Error("CRITICAL: Panic recovered in module '%s': %v", mod.Name(), r)
-				}
-			}()
-			mod.Start(ctx)
-		}(m)
-	}
-	logger.Info("System is running")
+		// The supervisor recovers panics and applies the module's restart policy.
+		e.launch(u)
+	}
+	if e.Watchdog != nil {
+		stopWatchdog := make(chan struct{})
+		defer close(stopWatchdog)
+		go e.runWatchdog(ctx, stopWatchdog)
+	}
+
+	e.mu.Lock()
+	e.boot.Total = time.Since(bootStart)
+	e.boot.HeapAfter = heapAlloc()
+	e.mu.Unlock()
+
+	e.log.Info("System is running")
+	e.BootReport().Log(e.log)
+	e.rt.Bus.Publish(TopicEngineRunning, int64(len(units)), nil, Source)
 
 	// --- Phase 3: Runtime Loop ---
-	// Block here forever.
-	// In bare-metal embedded systems, there is no OS signal to wait for.
-	// The loop exits only if Shutdown() is explicitly called (e.g., by an OTA update process).
-	<-e.shutdownCh
-	logger.Warn("Shutdown signal received. Stopping modules...")
+	// Block until something asks us to stop.
+	// In bare-metal embedded systems, there is no OS signal to wait for; the loop exits
+	// when Shutdown() is called (e.g., by an OTA update process) or a module escalates.
+	// On a host, the parent context provides the usual signal/test-runner cancellation.
+	select {
+	case <-e.shutdownCh:
+		e.log.Warn("Shutdown signal received. Stopping modules...")
+	case <-parent.Done():
+		e.setExit(ExitContext, "", parent.Err())
+		e.log.Warn("Parent context cancelled. Stopping modules...")
+	}
+	e.rt.Bus.Publish(TopicEngineStopping, int64(len(units)), nil, Source)
 
 	// --- Phase 4: Graceful Shutdown ---
-	// Cancel the context to notify all modules to exit their loops.
+	// Wait for any in-flight StopModule/StartModule call, then cancel the
+	// context to notify all modules to exit their loops.
+	e.opMu.Lock()
+	defer e.opMu.Unlock()
 	cancel()
 
-	// Execute Stop() methods for cleanup.
-	for _, m := range modules {
-		logger.Debug("Stopping module: %s", m.Name())
-		if err := m.Stop(); err != nil {
-			logger.Error("Error stopping module '%s': %v", m.Name(), err)
+	// Join the Start goroutines and execute Stop() methods in reverse boot order,
+	// so that a module is stopped before the modules it depends on.
+	// This covers modules started at runtime via StartModule as well.
+	report := e.stopUnits(all)
+	e.mu.Lock()
+	e.report = report
+	e.mu.Unlock()
+
+	if !report.Clean() {
+		e.log.Warn("Shutdown finished in %v with %d overruns", report.Duration, len(report.Overruns))
+	}
+	e.BootReport().LogShutdown(e.log)
+	e.rt.Bus.Publish(TopicEngineStopped, int64(len(report.Overruns)), report, Source)
+	e.log.Info("Gonnect Engine stopped.")
+	return e.exitErr()
+}
+
+// initModules calls Init on each module in boot order and returns the ones that succeeded.
+// If a critical module fails, the already initialized modules are stopped and
+// a fatal *ExitError is returned.
+func (e *Engine) initModules(units []*unit) ([]*unit, error) {
+	ready := make([]*unit, 0, len(units))
+	// failed is allocated lazily; on a healthy boot it stays nil.
+	var failed map[string]bool
+
+	for _, u := range units {
+		m := u.mod
+		name := m.Name()
+
+		err := failedDependency(m, failed)
+		if err == nil {
+			// State persisted before a CPU-resetting sleep is restored ahead of Init.
+			e.restoreState(m)
+			err = e.initUnit(u)
+		} else {
+			e.markFailed(u, err)
+		}
+		if err == nil {
+			ready = append(ready, u)
+			continue
+		}
+
+		if isCritical(m) {
+			e.log.Error("FATAL: Failed to initialize module '%s': %v", name, err)
+			// In embedded systems, failing Init of a critical module is usually unrecoverable.
+			// Undo the partial boot so hardware is left in a safe state before the caller resets.
+			e.abortBoot(ready)
+			e.setExit(ExitFatal, name, err)
+			return nil, e.exitErr()
 		}
+
+		e.log.Warn("Skipping optional module '%s': %v", name, err)
+		if failed == nil {
+			failed = make(map[string]bool)
+		}
+		failed[name] = true
+
+		e.mu.Lock()
+		e.failures = append(e.failures, InitFailure{Module: name, Err: err})
+		e.mu.Unlock()
+		e.publishModule(TopicModuleFailed, u)
 	}
 
-	logger.Info("Gonnect Engine stopped.")
+	return ready, nil
+}
+
+// abortBoot stops modules that were initialized but never launched, in reverse order.
+func (e *Engine) abortBoot(ready []*unit) {
+	for i := len(ready) - 1; i >= 0; i-- {
+		u := ready[i]
+		name := u.mod.Name()
+		if err := u.mod.Stop(); err != nil {
+			e.log.Error("Error stopping module '%s': %v", name, err)
+		}
+		e.rt.Resources.ReleaseAll(name)
+		e.setState(u, StateStopped)
+	}
+}
+
+// initUnit loads the module config, calls Init and records the outcome.
+// On failure, any resources the module claimed before failing are released.
+func (e *Engine) initUnit(u *unit) error {
+	e.log.Debug("Initializing module: %s", u.mod.Name())
+	if b, ok := u.mod.(Binder); ok {
+		b.Bind(e.rt)
+	}
+	if err := e.configure(u.mod); err != nil {
+		e.markFailed(u, err)
+		return err
+	}
+
+	heap, begin := heapAlloc(), time.Now()
+	err := u.mod.Init()
+	elapsed, grown := time.Since(begin), int64(heapAlloc())-int64(heap)
+
+	e.mu.Lock()
+	u.timing.Init, u.timing.InitHeap = elapsed, grown
+	e.mu.Unlock()
+
+	if err != nil {
+		e.markFailed(u, err)
+		return err
+	}
+	e.mu.Lock()
+	u.state, u.lastErr = StateInitialized, nil
+	e.mu.Unlock()
+	e.publishModule(TopicModuleInitialized, u)
+	return nil
+}
+
+// markFailed records an Init failure and reclaims the module's resources.
+func (e *Engine) markFailed(u *unit, err error) {
+	e.mu.Lock()
+	u.state, u.lastErr = StateFailed, err
+	e.mu.Unlock()
+
+	if n := e.rt.Resources.ReleaseAll(u.mod.Name()); n > 0 {
+		e.log.Debug("Released %d resources held by '%s'", n, u.mod.Name())
+	}
 }
 
 // Shutdown triggers a graceful shutdown of the engine.
-// It unblocks the Run() loop, cancels the context, and stops all modules.
+// It unblocks the RunContext() loop, cancels the context, and stops all modules.
 // Useful for OTA updates, deep sleep preparation, or soft restarts.
+// It is safe to call more than once.
+//
+// If Engine.Watchdog is set, it stays armed after Done() is closed; the caller
+// is responsible for feeding it from then on.
 func (e *Engine) Shutdown() {
-	close(e.shutdownCh)
-}
\ No newline at end of file
+	e.stopOnce.Do(func() {
+		close(e.shutdownCh)
+	})
+}
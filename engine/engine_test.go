@@ -0,0 +1,422 @@
+// engine/engine_test.go
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/magradze/gonnect"
+	"github.com/magradze/gonnect/config"
+	"github.com/magradze/gonnect/pkg/logger"
+)
+
+// quietLogger discards all output.
+type quietLogger struct{}
+
+func (quietLogger) Debug(string, ...any)     {}
+func (quietLogger) Info(string, ...any)      {}
+func (quietLogger) Warn(string, ...any)      {}
+func (quietLogger) Error(string, ...any)     {}
+func (quietLogger) SetLevel(logger.LogLevel) {}
+
+// testModule blocks in Start until its context is cancelled, unless run is set.
+type testModule struct {
+	name   string
+	deps   []string
+	run    func(ctx context.Context)
+	starts atomic.Int32
+	stops  atomic.Int32
+}
+
+func (m *testModule) Init() error         { return nil }
+func (m *testModule) Stop() error         { m.stops.Add(1); return nil }
+func (m *testModule) Name() string        { return m.name }
+func (m *testModule) DependsOn() []string { return m.deps }
+func (m *testModule) Start(ctx context.Context) {
+	m.starts.Add(1)
+	if m.run != nil {
+		m.run(ctx)
+		return
+	}
+	<-ctx.Done()
+}
+
+// newTestEngine returns an isolated engine running the given modules.
+func newTestEngine(t *testing.T, mods ...gonnect.Module) *Engine {
+	t.Helper()
+	rt := NewRuntime()
+	rt.Log = quietLogger{}
+	for _, m := range mods {
+		rt.Registry.RegisterModule(m)
+	}
+	e := NewWithRuntime(nil, rt)
+	e.ShutdownTimeout = 200 * time.Millisecond
+	return e
+}
+
+// start runs the engine in the background and waits until it is running.
+func start(t *testing.T, e *Engine, ctx context.Context) <-chan error {
+	t.Helper()
+	errCh := make(chan error, 1)
+	go func() { errCh <- e.RunContext(ctx) }()
+
+	waitFor(t, "engine running", func() bool {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		return e.boot.Total > 0
+	})
+	return errCh
+}
+
+// waitFor polls cond until it holds or a second has passed.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func stateOf(e *Engine, name string) ModuleState {
+	st, _ := e.Module(name)
+	return st.State
+}
+
+func waitDone(t *testing.T, e *Engine) {
+	t.Helper()
+	select {
+	case <-e.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("engine did not stop")
+	}
+}
+
+func TestRunContextShutdown(t *testing.T) {
+	a := &testModule{name: "a"}
+	b := &testModule{name: "b", deps: []string{"a"}}
+	e := newTestEngine(t, b, a)
+
+	errCh := start(t, e, context.Background())
+	e.Shutdown()
+	e.Shutdown() // idempotent
+
+	if err := <-errCh; err != nil {
+		t.Fatalf("RunContext returned %v, want nil", err)
+	}
+	waitDone(t, e)
+	if a.stops.Load() != 1 || b.stops.Load() != 1 {
+		t.Fatalf("stops = %d/%d, want 1/1", a.stops.Load(), b.stops.Load())
+	}
+	if !e.LastShutdown().Clean() {
+		t.Fatalf("unexpected overruns: %v", e.LastShutdown().Overruns)
+	}
+	if err := e.RunContext(context.Background()); !errors.Is(err, ErrAlreadyStarted) {
+		t.Fatalf("second RunContext returned %v", err)
+	}
+}
+
+func TestRunContextParentCancel(t *testing.T) {
+	e := newTestEngine(t, &testModule{name: "a"})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errCh := start(t, e, ctx)
+	cancel()
+
+	var xe *ExitError
+	if err := <-errCh; !errors.As(err, &xe) || xe.Reason != ExitContext {
+		t.Fatalf("RunContext returned %v, want an ExitContext error", err)
+	}
+}
+
+func TestStopAndStartModule(t *testing.T) {
+	a := &testModule{name: "a"}
+	b := &testModule{name: "b", deps: []string{"a"}}
+	e := newTestEngine(t, a, b)
+	start(t, e, context.Background())
+	defer waitDone(t, e)
+	defer e.Shutdown()
+
+	if err := e.StopModule("a"); err != nil {
+		t.Fatal(err)
+	}
+	if stateOf(e, "a") != StateStopped || stateOf(e, "b") != StateStopped {
+		t.Fatalf("states = %s/%s, want stopped", stateOf(e, "a"), stateOf(e, "b"))
+	}
+	if err := e.StartModule("b"); !errors.Is(err, ErrDependencyInactive) {
+		t.Fatalf("StartModule(b) = %v, want ErrDependencyInactive", err)
+	}
+	if err := e.RestartModule("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.StartModule("b"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "b running", func() bool { return stateOf(e, "b") == StateRunning })
+	if err := e.StartModule("b"); !errors.Is(err, ErrModuleActive) {
+		t.Fatalf("StartModule on a running module = %v", err)
+	}
+}
+
+func TestSuspendThenShutdown(t *testing.T) {
+	entered := make(chan struct{})
+	var e *Engine
+	power := &testModule{name: "power", run: func(ctx context.Context) {
+		close(entered)
+		// Zero means "until an external wake source fires"; Shutdown must wake it.
+		e.Suspend(ctx, 0)
+		<-ctx.Done()
+	}}
+	e = newTestEngine(t, &testModule{name: "a"}, power)
+	e.Sleep = TimerSleep{}
+
+	errCh := start(t, e, context.Background())
+	<-entered
+	waitFor(t, "a suspended", func() bool { return stateOf(e, "a") == StateSuspended })
+
+	e.Shutdown()
+	waitDone(t, e)
+	if err := <-errCh; err != nil {
+		t.Fatalf("RunContext returned %v", err)
+	}
+}
+
+func TestSuspendFromModule(t *testing.T) {
+	type result struct {
+		cause gonnect.WakeCause
+		err   error
+	}
+	res := make(chan result, 1)
+	var e *Engine
+	power := &testModule{name: "power", run: func(ctx context.Context) {
+		cause, err := e.Suspend(ctx, 10*time.Millisecond)
+		res <- result{cause, err}
+		<-ctx.Done()
+	}}
+	a := &testModule{name: "a"}
+	e = newTestEngine(t, a, power)
+	e.Sleep = TimerSleep{}
+
+	start(t, e, context.Background())
+	defer waitDone(t, e)
+	defer e.Shutdown()
+
+	r := <-res
+	if r.err != nil || r.cause != gonnect.WakeTimer {
+		t.Fatalf("Suspend = %v, %v; want timer, nil", r.cause, r.err)
+	}
+	waitFor(t, "a relaunched", func() bool { return a.starts.Load() == 2 && stateOf(e, "a") == StateRunning })
+	if power.starts.Load() != 1 || stateOf(e, "power") != StateRunning {
+		t.Fatalf("caller was restarted (%d starts, %s)", power.starts.Load(), stateOf(e, "power"))
+	}
+}
+
+func TestSuspendAbortRelaunchesOverrun(t *testing.T) {
+	release := make(chan struct{})
+	slow := &testModule{name: "slow", run: func(ctx context.Context) {
+		<-ctx.Done()
+		<-release
+	}}
+	res := make(chan error, 1)
+	var e *Engine
+	power := &testModule{name: "power", run: func(ctx context.Context) {
+		_, err := e.Suspend(ctx, time.Hour)
+		res <- err
+		<-ctx.Done()
+	}}
+	e = newTestEngine(t, slow, power)
+	e.Sleep = TimerSleep{}
+	e.ShutdownTimeout = 20 * time.Millisecond
+
+	start(t, e, context.Background())
+	defer waitDone(t, e)
+	defer e.Shutdown()
+
+	if err := <-res; !errors.Is(err, ErrSuspendAborted) {
+		t.Fatalf("Suspend = %v, want ErrSuspendAborted", err)
+	}
+	close(release)
+	waitFor(t, "slow relaunched", func() bool { return slow.starts.Load() == 2 && stateOf(e, "slow") == StateRunning })
+	if err := e.StartModule("slow"); !errors.Is(err, ErrModuleActive) {
+		t.Fatalf("StartModule = %v, want ErrModuleActive", err)
+	}
+}
+
+func TestRestartModuleOverrun(t *testing.T) {
+	release := make(chan struct{})
+	var running, maxRunning atomic.Int32
+	slow := &testModule{name: "slow", run: func(ctx context.Context) {
+		if n := running.Add(1); n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		defer running.Add(-1)
+		<-ctx.Done()
+		<-release
+	}}
+	e := newTestEngine(t, slow)
+	e.ShutdownTimeout = 20 * time.Millisecond
+	start(t, e, context.Background())
+	defer waitDone(t, e)
+	defer e.Shutdown()
+
+	if err := e.RestartModule("slow"); !errors.Is(err, ErrStopOverrun) {
+		t.Fatalf("RestartModule = %v, want ErrStopOverrun", err)
+	}
+	if err := e.StartModule("slow"); !errors.Is(err, ErrModuleBusy) {
+		t.Fatalf("StartModule while Start is still running = %v, want ErrModuleBusy", err)
+	}
+
+	close(release)
+	var err error
+	waitFor(t, "old Start returned", func() bool {
+		err = e.StartModule("slow")
+		return !errors.Is(err, ErrModuleBusy)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "slow running", func() bool { return stateOf(e, "slow") == StateRunning })
+	if n := maxRunning.Load(); n != 1 {
+		t.Fatalf("%d Start goroutines ran at once", n)
+	}
+}
+
+func TestSuspendSkipsFinishedModules(t *testing.T) {
+	oneShot := &testModule{name: "oneshot", run: func(context.Context) {}}
+	crashed := crashing("crashed", gonnect.RestartPolicy{})
+	res := make(chan error, 1)
+	e := newTestEngine(t, oneShot, crashed, &testModule{name: "other"})
+	e.Sleep = TimerSleep{}
+	start(t, e, context.Background())
+	defer waitDone(t, e)
+	defer e.Shutdown()
+
+	waitFor(t, "modules finished", func() bool {
+		return stateOf(e, "oneshot") == StateExited && stateOf(e, "crashed") == StatePanicked
+	})
+	go func() {
+		_, err := e.Suspend(context.Background(), time.Millisecond)
+		res <- err
+	}()
+	if err := <-res; err != nil {
+		t.Fatal(err)
+	}
+	if oneShot.starts.Load() != 1 || crashed.starts.Load() != 1 {
+		t.Fatalf("starts = %d/%d after suspend, want 1/1", oneShot.starts.Load(), crashed.starts.Load())
+	}
+	if stateOf(e, "oneshot") != StateExited || stateOf(e, "crashed") != StatePanicked {
+		t.Fatalf("states = %s/%s", stateOf(e, "oneshot"), stateOf(e, "crashed"))
+	}
+}
+
+func TestSuspendKeepsWatchdogFed(t *testing.T) {
+	wd := &SoftWatchdog{}
+	res := make(chan error, 1)
+	var e *Engine
+	power := &watchedModule{interval: 20 * time.Millisecond}
+	power.testModule = testModule{name: "power", run: func(ctx context.Context) {
+		Heartbeat(ctx)
+		_, err := e.Suspend(ctx, 150*time.Millisecond)
+		res <- err
+		<-ctx.Done()
+	}}
+	e = newTestEngine(t, power)
+	e.Sleep = TimerSleep{}
+	e.Watchdog = wd
+	e.WatchdogTimeout = 40 * time.Millisecond
+	start(t, e, context.Background())
+	defer waitDone(t, e)
+	defer e.Shutdown()
+
+	if err := <-res; err != nil {
+		t.Fatal(err)
+	}
+	if wd.Expired() {
+		t.Fatal("watchdog expired while the caller was blocked in Suspend")
+	}
+}
+
+// memStore keeps the config blob in memory.
+type memStore struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+func (s *memStore) Load() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data == nil {
+		return nil, config.ErrNoConfig
+	}
+	return s.data, nil
+}
+
+func (s *memStore) Save(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = append([]byte(nil), data...)
+	return nil
+}
+
+func (s *memStore) Clear() error { return s.Save(nil) }
+
+type counterState struct{ N int }
+
+// persistentModule keeps a counter across CPU-resetting sleeps.
+type persistentModule struct {
+	testModule
+	state counterState
+}
+
+func (m *persistentModule) State() any { return &m.state }
+
+// resetSleep reports a fixed boot wake cause.
+type resetSleep struct {
+	TimerSleep
+	cause gonnect.WakeCause
+}
+
+func (s resetSleep) BootWakeCause() gonnect.WakeCause { return s.cause }
+
+func TestRestoreStateOnlyAfterWake(t *testing.T) {
+	tests := []struct {
+		cause gonnect.WakeCause
+		want  int
+	}{
+		{gonnect.WakeTimer, 7},
+		{gonnect.WakePin, 7},
+		{gonnect.WakeUnknown, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cause.String(), func(t *testing.T) {
+			store := &memStore{}
+			if err := config.NewManager(store).SaveSection(statePrefix+"counter", &counterState{N: 7}); err != nil {
+				t.Fatal(err)
+			}
+
+			m := &persistentModule{testModule: testModule{name: "counter"}}
+			rt := NewRuntime()
+			rt.Log = quietLogger{}
+			rt.Registry.RegisterModule(m)
+			e := NewWithRuntime(store, rt)
+			e.Sleep = resetSleep{cause: tt.cause}
+			start(t, e, context.Background())
+			e.Shutdown()
+			waitDone(t, e)
+
+			if m.state.N != tt.want {
+				t.Fatalf("restored N = %d, want %d", m.state.N, tt.want)
+			}
+			var left counterState
+			if err := config.NewManager(store).LoadSection(statePrefix+"counter", &left); !errors.Is(err, config.ErrNoConfig) {
+				t.Fatalf("state section still stored after boot (err %v)", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// engine/events.go
+package engine
+
+// Source is the Event.Source value used for events published by the Engine.
+const Source = "engine"
+
+// Well-known engine topics. Event.Value carries the number of modules involved.
+const (
+	TopicEngineStarting = "system/engine/starting"
+	TopicEngineRunning  = "system/engine/running"
+	TopicEngineStopping = "system/engine/stopping"
+	// TopicEngineStopped carries the ShutdownReport as payload.
+	TopicEngineStopped = "system/engine/stopped"
+	// TopicEngineSuspending is published before modules are quiesced for sleep.
+	TopicEngineSuspending = "system/engine/suspending"
+	// TopicEngineResumed carries the gonnect.WakeCause as Event.Value.
+	TopicEngineResumed = "system/engine/resumed"
+)
+
+// Well-known module topics. The payload is always a ModuleStatus snapshot.
+const (
+	TopicModuleInitialized = "system/module/initialized"
+	// TopicModuleFailed is published when an optional module is skipped during boot.
+	TopicModuleFailed     = "system/module/failed"
+	TopicModuleStarted    = "system/module/started"
+	TopicModuleExited     = "system/module/exited"
+	TopicModulePanicked   = "system/module/panicked"
+	TopicModuleRestarting = "system/module/restarting"
+	// TopicModuleEscalated is published when a module exhausts its restart budget.
+	TopicModuleEscalated = "system/module/escalated"
+	TopicModuleStopped   = "system/module/stopped"
+)
+
+// publishModule publishes a module lifecycle event with a status snapshot.
+func (e *Engine) publishModule(topic string, u *unit) {
+	e.mu.Lock()
+	st := u.status()
+	e.mu.Unlock()
+
+	e.rt.Bus.Publish(topic, int64(st.Restarts), st, Source)
+}
@@ -0,0 +1,88 @@
+// engine/exit.go
+package engine
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	// ErrAlreadyStarted is returned when Run/RunContext is called more than once.
+	ErrAlreadyStarted = errors.New("engine: already started")
+	// ErrRestartBudget is the cause reported when a supervised module escalates.
+	ErrRestartBudget = errors.New("engine: restart budget exhausted")
+)
+
+// ExitReason classifies why RunContext returned.
+type ExitReason uint8
+
+const (
+	// ExitShutdown means Shutdown() was called. RunContext returns nil in this case.
+	ExitShutdown ExitReason = iota
+	// ExitContext means the parent context was cancelled.
+	ExitContext
+	// ExitFatal means boot failed (dependency resolution or a critical Init).
+	ExitFatal
+	// ExitEscalation means a supervised module exhausted its restart budget.
+	ExitEscalation
+	// exitLimit is used for boundary checking in the String method.
+	exitLimit
+)
+
+var exitNames = [...]string{
+	"shutdown",
+	"context",
+	"fatal",
+	"escalation",
+}
+
+// String returns the lowercase name of the reason.
+func (r ExitReason) String() string {
+	if r < exitLimit {
+		return exitNames[r]
+	}
+	return "unknown(" + strconv.Itoa(int(r)) + ")"
+}
+
+// ExitError is returned by RunContext when the engine stops for any reason
+// other than an explicit Shutdown().
+type ExitError struct {
+	Reason ExitReason
+	// Module is the module that caused the exit, if any.
+	Module string
+	Err    error
+}
+
+func (e *ExitError) Error() string {
+	msg := "engine: exit (" + e.Reason.String() + ")"
+	if e.Module != "" {
+		msg += " in module '" + e.Module + "'"
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// setExit records the first exit cause. Later causes are ignored.
+func (e *Engine) setExit(reason ExitReason, module string, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.exit == nil {
+		e.exit = &ExitError{Reason: reason, Module: module, Err: err}
+	}
+}
+
+// exitErr returns the recorded exit cause, or nil for a requested shutdown.
+func (e *Engine) exitErr() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.exit == nil {
+		return nil
+	}
+	return e.exit
+}
@@ -0,0 +1,57 @@
+// engine/failure.go
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/magradze/gonnect"
+)
+
+// ErrDependencyFailed is reported for modules skipped because a dependency failed Init.
+var ErrDependencyFailed = errors.New("engine: dependency failed")
+
+// InitFailure describes an optional module that was skipped during boot.
+type InitFailure struct {
+	Module string
+	Err    error
+}
+
+// isCritical reports whether a failure of 'm' must halt the system.
+func isCritical(m gonnect.Module) bool {
+	if c, ok := m.(gonnect.Criticality); ok {
+		return c.Critical()
+	}
+	return true
+}
+
+// failedDependency returns an error if any dependency of 'm' is in the failed set.
+func failedDependency(m gonnect.Module, failed map[string]bool) error {
+	d, ok := m.(gonnect.Dependent)
+	if !ok {
+		return nil
+	}
+	for _, dep := range d.DependsOn() {
+		if failed[dep] {
+			return fmt.Errorf("%w: '%s'", ErrDependencyFailed, dep)
+		}
+	}
+	return nil
+}
+
+// Failures returns the optional modules that were skipped during boot.
+func (e *Engine) Failures() []InitFailure {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	out := make([]InitFailure, len(e.failures))
+	copy(out, e.failures)
+	return out
+}
+
+// Degraded reports whether the system booted without one or more optional modules.
+func (e *Engine) Degraded() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.failures) > 0
+}
@@ -0,0 +1,273 @@
+// engine/power.go
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/magradze/gonnect"
+)
+
+var (
+	// ErrNoSleepBackend is returned by Suspend when Engine.Sleep is nil.
+	ErrNoSleepBackend = errors.New("engine: no sleep backend")
+	// ErrSuspendAborted is returned when a module could not be quiesced.
+	ErrSuspendAborted = errors.New("engine: suspend aborted")
+)
+
+// statePrefix namespaces persisted module state inside the config store.
+const statePrefix = "state/"
+
+// SleepBackend puts the device into a low-power mode.
+type SleepBackend interface {
+	// Sleep blocks for up to d (zero means until an external wake source fires)
+	// and reports why the device woke. Backends that reset the CPU never return.
+	Sleep(ctx context.Context, d time.Duration) (gonnect.WakeCause, error)
+}
+
+// BootWakeReporter is an optional SleepBackend interface for devices that reset
+// on wake-up. It reports the wake cause of the current boot.
+type BootWakeReporter interface {
+	BootWakeCause() gonnect.WakeCause
+}
+
+// TimerSleep is a SleepBackend for host builds. It simply waits.
+type TimerSleep struct{}
+
+// Sleep waits for d or until ctx is cancelled.
+func (TimerSleep) Sleep(ctx context.Context, d time.Duration) (gonnect.WakeCause, error) {
+	if d <= 0 {
+		<-ctx.Done()
+		return gonnect.WakeExternal, nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return gonnect.WakeTimer, nil
+	case <-ctx.Done():
+		return gonnect.WakeExternal, nil
+	}
+}
+
+// WakeCause returns the cause of the most recent wake-up
+// (gonnect.WakeUnknown on a cold boot without a BootWakeReporter).
+func (e *Engine) WakeCause() gonnect.WakeCause {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.wake
+}
+
+// Suspend quiesces all running modules, persists their state via Engine.Config,
+// enters the sleep backend for up to d, and resumes the modules on wake.
+//
+// Running modules are quiesced in reverse boot order: their Start context is cancelled
+// and joined, then gonnect.Suspender.Suspend is called. Stop is not called and resources
+// stay locked. On wake, Resume is called in boot order and Start is relaunched.
+//
+// Suspend is normally called from a module's Start goroutine (Run blocks main);
+// pass that module's ctx. The calling module is not cancelled, since it is blocked
+// in Suspend, but its Suspend/Resume hooks run and its state is persisted.
+// Shutdown wakes the engine from sleep.
+func (e *Engine) Suspend(ctx context.Context, d time.Duration) (gonnect.WakeCause, error) {
+	if e.Sleep == nil {
+		return gonnect.WakeUnknown, ErrNoSleepBackend
+	}
+
+	e.opMu.Lock()
+	defer e.opMu.Unlock()
+
+	e.mu.Lock()
+	runCtx := e.ctx
+	units := e.units
+	e.mu.Unlock()
+	if runCtx == nil || runCtx.Err() != nil {
+		return gonnect.WakeUnknown, ErrNotRunning
+	}
+	caller, _ := ctx.Value(unitKey{}).(*unit)
+
+	// The caller sends no heartbeats while it is blocked here; keep the
+	// watchdog from counting it as stalled.
+	e.mu.Lock()
+	e.suspender = caller
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		e.suspender = nil
+		e.mu.Unlock()
+		if caller != nil {
+			caller.lastBeat.Store(time.Now().UnixNano())
+		}
+	}()
+
+	e.log.Info("Suspending system...")
+	e.rt.Bus.Publish(TopicEngineSuspending, 0, nil, Source)
+
+	// --- Quiesce ---
+	quiesced := make([]*unit, 0, len(units))
+	for i := len(units) - 1; i >= 0; i-- {
+		u := units[i]
+		// Modules whose Start already returned (one-shot, crashed or out of
+		// restart budget) stay as they are.
+		if !e.isRunning(u) {
+			continue
+		}
+		if err := e.quiesce(u, u == caller); err != nil {
+			e.log.Error("Suspend aborted: %v", err)
+			e.resumeUnits(quiesced, gonnect.WakeAborted, caller)
+			return gonnect.WakeAborted, fmt.Errorf("%w: %v", ErrSuspendAborted, err)
+		}
+		quiesced = append(quiesced, u)
+	}
+
+	// --- Persist ---
+	e.saveStates(quiesced)
+
+	// --- Sleep ---
+	// RunContext only cancels its context after acquiring opMu, which Suspend
+	// holds, so Shutdown must interrupt the sleep directly.
+	sleepCtx, wake := context.WithCancel(runCtx)
+	go func() {
+		select {
+		case <-e.shutdownCh:
+			wake()
+		case <-sleepCtx.Done():
+		}
+	}()
+
+	// Interrupts still queue into ISR sources, but nothing polls them while asleep.
+	e.rt.Bus.PausePump()
+	e.log.Info("Entering low-power mode (%v)", d)
+	cause, err := e.Sleep.Sleep(sleepCtx, d)
+	wake()
+	e.rt.Bus.ResumePump()
+	if err != nil {
+		e.log.Error("Sleep backend failed: %v", err)
+		cause = gonnect.WakeAborted
+	}
+
+	e.mu.Lock()
+	e.wake = cause
+	e.mu.Unlock()
+
+	// --- Resume ---
+	e.log.Info("Woke up (cause: %s). Resuming modules...", cause)
+	e.resumeUnits(quiesced, cause, caller)
+	e.rt.Bus.Publish(TopicEngineResumed, int64(cause), nil, Source)
+	return cause, err
+}
+
+// quiesce cancels a unit's Start, waits for it and calls Suspend.
+// The caller's own unit (self) keeps running; only its hook is called.
+func (e *Engine) quiesce(u *unit, self bool) error {
+	name := u.mod.Name()
+	budget := e.shutdownTimeout(u.mod)
+
+	if !self {
+		e.mu.Lock()
+		cancel, done := u.cancel, u.done
+		e.mu.Unlock()
+		cancel()
+
+		if !waitTimeout(done, budget) {
+			// The rollback cannot relaunch a Start that is still running;
+			// do it once the overrunning Start finally returns.
+			go e.relaunchAfter(u, done)
+			return fmt.Errorf("module '%s' did not exit Start within %v", name, budget)
+		}
+	}
+
+	if s, ok := u.mod.(gonnect.Suspender); ok {
+		if err := s.Suspend(); err != nil {
+			// Start already returned; relaunch it with the others during rollback.
+			if !self {
+				e.setState(u, StateSuspended)
+			}
+			e.resumeUnits([]*unit{u}, gonnect.WakeAborted, u)
+			if !self {
+				e.launch(u)
+			}
+			return fmt.Errorf("module '%s' failed to suspend: %w", name, err)
+		}
+	}
+
+	if !self {
+		e.setState(u, StateSuspended)
+	}
+	return nil
+}
+
+// relaunchAfter restarts a unit whose Start overran a suspend, once it has returned.
+// Nothing is relaunched if the module was stopped or the engine shut down meanwhile.
+func (e *Engine) relaunchAfter(u *unit, done <-chan struct{}) {
+	<-done
+
+	e.opMu.Lock()
+	defer e.opMu.Unlock()
+
+	e.mu.Lock()
+	ok := u.active && u.done == done && e.ctx != nil && e.ctx.Err() == nil
+	e.mu.Unlock()
+	if ok {
+		e.log.Warn("Relaunching module '%s' after its Start overran a suspend", u.mod.Name())
+		e.launch(u)
+	}
+}
+
+// resumeUnits calls Resume and relaunches Start, except for the calling unit
+// which never stopped. 'units' is in reverse boot order.
+func (e *Engine) resumeUnits(units []*unit, cause gonnect.WakeCause, caller *unit) {
+	for i := len(units) - 1; i >= 0; i-- {
+		u := units[i]
+		if s, ok := u.mod.(gonnect.Suspender); ok {
+			if err := s.Resume(cause); err != nil {
+				e.log.Error("Module '%s' failed to resume: %v", u.mod.Name(), err)
+				e.mu.Lock()
+				u.lastErr = err
+				e.mu.Unlock()
+			}
+		}
+		if u != caller {
+			e.launch(u)
+		}
+	}
+}
+
+// saveStates persists the state of every gonnect.Persistent module.
+func (e *Engine) saveStates(units []*unit) {
+	if e.Config == nil {
+		return
+	}
+	for _, u := range units {
+		if p, ok := u.mod.(gonnect.Persistent); ok {
+			if err := e.Config.SaveSection(statePrefix+u.mod.Name(), p.State()); err != nil {
+				e.log.Error("Failed to persist state of '%s': %v", u.mod.Name(), err)
+			}
+		}
+	}
+}
+
+// restoreState loads the state a gonnect.Persistent module saved before a
+// CPU-resetting sleep. It is only restored when this boot is a wake-up reported
+// by a BootWakeReporter, and deleted either way, so that a later cold boot,
+// power cycle or firmware update starts from a clean state.
+func (e *Engine) restoreState(m gonnect.Module) {
+	p, ok := m.(gonnect.Persistent)
+	if !ok || e.Config == nil {
+		return
+	}
+	name := statePrefix + m.Name()
+
+	switch e.WakeCause() {
+	case gonnect.WakeTimer, gonnect.WakePin, gonnect.WakeExternal:
+		if err := e.Config.LoadSection(name, p.State()); err == nil {
+			e.log.Debug("Restored persisted state of '%s'", m.Name())
+		}
+	}
+	if err := e.Config.DeleteSection(name); err != nil {
+		e.log.Warn("Failed to clear persisted state of '%s': %v", m.Name(), err)
+	}
+}
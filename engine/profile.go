@@ -0,0 +1,89 @@
+// engine/profile.go
+package engine
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/magradze/gonnect/pkg/logger"
+)
+
+// ModuleTiming holds the lifecycle measurements of a single module.
+// Heap deltas are HeapAlloc differences and can be negative if a GC ran.
+type ModuleTiming struct {
+	Name string
+
+	Init     time.Duration
+	InitHeap int64
+
+	// Ready is the offset from engine start to the launch of Start.
+	Ready time.Duration
+	// Start is how long the last Start call ran; zero while it is still running.
+	Start time.Duration
+
+	// Stop fields are filled in once the module has been stopped.
+	Stop     time.Duration
+	StopHeap int64
+}
+
+// BootReport summarizes how long the system took to boot and where the time went.
+type BootReport struct {
+	// Total is the time from RunContext entry to "System is running".
+	Total time.Duration
+	// HeapBefore and HeapAfter are HeapAlloc at engine start and when running.
+	HeapBefore uint64
+	HeapAfter  uint64
+	Modules    []ModuleTiming
+}
+
+// heapAlloc returns the current heap usage.
+// Note: ReadMemStats briefly stops the world; it is only used around lifecycle calls.
+func heapAlloc() uint64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
+
+// BootReport returns the timing measurements collected so far, in boot order.
+func (e *Engine) BootReport() BootReport {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	r := e.boot
+	r.Modules = make([]ModuleTiming, len(e.units))
+	for i, u := range e.units {
+		r.Modules[i] = u.timing
+		r.Modules[i].Name = u.mod.Name()
+	}
+	return r
+}
+
+// Log prints the boot-time table. START is only known for Start calls that
+// already returned; long-running modules show "running".
+func (r BootReport) Log(l logger.Logger) {
+	l.Info("Boot finished in %v (heap %d -> %d bytes)", r.Total, r.HeapBefore, r.HeapAfter)
+	l.Info("%-20s %10s %10s %10s %10s", "MODULE", "INIT", "HEAP", "READY", "START")
+	for _, m := range r.Modules {
+		l.Info("%-20s %10v %10d %10v %10s", m.Name, m.Init, m.InitHeap, m.Ready, startColumn(m))
+	}
+}
+
+// LogShutdown prints how long each module ran and how long its Stop took.
+func (r BootReport) LogShutdown(l logger.Logger) {
+	l.Info("%-20s %10s %10s %10s", "MODULE", "START", "STOP", "HEAP")
+	for _, m := range r.Modules {
+		l.Info("%-20s %10s %10v %10d", m.Name, startColumn(m), m.Stop, m.StopHeap)
+	}
+}
+
+// startColumn formats the Start duration: "-" if Start was never launched,
+// "running" if it has not returned yet.
+func startColumn(m ModuleTiming) string {
+	switch {
+	case m.Ready == 0:
+		return "-"
+	case m.Start == 0:
+		return "running"
+	}
+	return m.Start.String()
+}
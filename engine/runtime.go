@@ -0,0 +1,80 @@
+// engine/runtime.go
+package engine
+
+import (
+	"context"
+
+	"github.com/magradze/gonnect/event"
+	"github.com/magradze/gonnect/pkg/logger"
+	"github.com/magradze/gonnect/registry"
+	"github.com/magradze/gonnect/resource"
+)
+
+// Runtime bundles the services scoped to one Engine instance.
+// Two engines with separate runtimes share no state, which allows running
+// them side by side in one process or in parallel tests.
+type Runtime struct {
+	Bus       *event.Bus
+	Registry  *registry.Registry
+	Resources *resource.Manager
+	Log       logger.Logger
+}
+
+// DefaultRuntime returns a Runtime backed by the package-level instances
+// (event.Publish, registry.RegisterModule, resource.Lock, logger.Info, ...).
+func DefaultRuntime() *Runtime {
+	return &Runtime{
+		Bus:       event.Default(),
+		Registry:  registry.Default(),
+		Resources: resource.Default(),
+		Log:       logger.Default(),
+	}
+}
+
+// NewRuntime returns a Runtime with fresh, isolated instances.
+// The logger is still the global one, since output goes to the same console.
+func NewRuntime() *Runtime {
+	return &Runtime{
+		Bus:       event.NewBus(),
+		Registry:  registry.New(),
+		Resources: resource.NewManager(),
+		Log:       logger.Default(),
+	}
+}
+
+// Binder is an optional interface for modules that use an engine-scoped Runtime
+// instead of the package-level defaults. Bind is called before every Init.
+type Binder interface {
+	Bind(rt *Runtime)
+}
+
+// runtimeKey is the context key under which the Runtime is stored.
+type runtimeKey struct{}
+
+// RuntimeFrom returns the Runtime of the Engine that launched the module's Start.
+// It falls back to DefaultRuntime() for contexts not created by an Engine.
+func RuntimeFrom(ctx context.Context) *Runtime {
+	if rt, ok := ctx.Value(runtimeKey{}).(*Runtime); ok {
+		return rt
+	}
+	return DefaultRuntime()
+}
+
+// withDefaults fills missing fields from the process-wide instances.
+func (rt *Runtime) withDefaults() *Runtime {
+	out := *rt
+	def := DefaultRuntime()
+	if out.Bus == nil {
+		out.Bus = def.Bus
+	}
+	if out.Registry == nil {
+		out.Registry = def.Registry
+	}
+	if out.Resources == nil {
+		out.Resources = def.Resources
+	}
+	if out.Log == nil {
+		out.Log = def.Log
+	}
+	return &out
+}
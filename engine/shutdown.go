@@ -0,0 +1,146 @@
+// engine/shutdown.go
+package engine
+
+import (
+	"time"
+
+	"github.com/magradze/gonnect"
+)
+
+// DefaultShutdownTimeout is the per-module budget used when Engine.ShutdownTimeout is zero.
+const DefaultShutdownTimeout = 2 * time.Second
+
+// Shutdown phases reported in an Overrun.
+const (
+	PhaseStart = "start" // Start did not return after the context was cancelled.
+	PhaseStop  = "stop"  // Stop did not return in time.
+)
+
+// Overrun identifies a module that exceeded its shutdown budget.
+type Overrun struct {
+	Module string
+	Phase  string
+}
+
+// ShutdownReport summarizes the last graceful shutdown.
+// A report with no Overruns means every module exited within its budget.
+type ShutdownReport struct {
+	Duration time.Duration
+	Overruns []Overrun
+}
+
+// Clean reports whether all modules stopped within their budgets.
+func (r ShutdownReport) Clean() bool {
+	return len(r.Overruns) == 0
+}
+
+// shutdownTimeout returns the budget for a module.
+func (e *Engine) shutdownTimeout(m gonnect.Module) time.Duration {
+	if t, ok := m.(gonnect.ShutdownTimeout); ok {
+		if d := t.ShutdownTimeout(); d > 0 {
+			return d
+		}
+	}
+	if e.ShutdownTimeout > 0 {
+		return e.ShutdownTimeout
+	}
+	return DefaultShutdownTimeout
+}
+
+// waitTimeout blocks until ch is closed or the timeout expires.
+// It returns false on timeout.
+func waitTimeout(ch <-chan struct{}, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ch:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// stopUnits stops every active unit in reverse boot order.
+func (e *Engine) stopUnits(units []*unit) ShutdownReport {
+	begin := time.Now()
+	var report ShutdownReport
+
+	for i := len(units) - 1; i >= 0; i-- {
+		if !e.isActive(units[i]) {
+			continue
+		}
+		report.Overruns = append(report.Overruns, e.stopUnit(units[i])...)
+	}
+
+	report.Duration = time.Since(begin)
+	return report
+}
+
+// stopUnit cancels a unit's context, joins its Start goroutine, calls Stop and
+// releases the resources it still holds. It returns the phases that overran.
+func (e *Engine) stopUnit(u *unit) []Overrun {
+	name := u.mod.Name()
+	budget := e.shutdownTimeout(u.mod)
+	var overruns []Overrun
+
+	e.mu.Lock()
+	u.active = false
+	cancel, done := u.cancel, u.done
+	e.mu.Unlock()
+	cancel()
+
+	if !waitTimeout(done, budget) {
+		e.log.Error("Module '%s' did not exit Start within %v", name, budget)
+		overruns = append(overruns, Overrun{Module: name, Phase: PhaseStart})
+	}
+
+	e.log.Debug("Stopping module: %s", name)
+	heap, begin := heapAlloc(), time.Now()
+	// Stop runs in its own goroutine so a blocked driver cannot hang the shutdown.
+	// If it overruns, the goroutine is abandoned; the caller is expected to reset.
+	stopped := make(chan struct{})
+	e.mu.Lock()
+	u.stopped = stopped
+	e.mu.Unlock()
+	go func() {
+		defer close(stopped)
+		if err := u.mod.Stop(); err != nil {
+			e.log.Error("Error stopping module '%s': %v", name, err)
+			e.mu.Lock()
+			u.lastErr = err
+			e.mu.Unlock()
+		}
+	}()
+
+	if !waitTimeout(stopped, budget) {
+		e.log.Error("Module '%s' did not return from Stop within %v", name, budget)
+		overruns = append(overruns, Overrun{Module: name, Phase: PhaseStop})
+	}
+
+	if n := e.rt.Resources.ReleaseAll(name); n > 0 {
+		e.log.Warn("Module '%s' left %d resources locked after Stop", name, n)
+	}
+
+	e.mu.Lock()
+	u.timing.Stop, u.timing.StopHeap = time.Since(begin), int64(heapAlloc())-int64(heap)
+	e.mu.Unlock()
+
+	e.setState(u, StateStopped)
+	e.publishModule(TopicModuleStopped, u)
+	return overruns
+}
+
+// LastShutdown returns the report of the most recent shutdown.
+// It is only meaningful after Done() is closed.
+func (e *Engine) LastShutdown() ShutdownReport {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.report
+}
+
+// Done returns a channel that is closed once Run has fully stopped all modules.
+// OTA and deep sleep paths can call Shutdown() and then wait on Done().
+func (e *Engine) Done() <-chan struct{} {
+	return e.doneCh
+}
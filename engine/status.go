@@ -0,0 +1,116 @@
+// engine/status.go
+package engine
+
+import (
+	"strconv"
+	"time"
+)
+
+// ModuleState is the lifecycle stage of a module as tracked by the Engine.
+type ModuleState uint8
+
+const (
+	// StateRegistered means the module is known but Init has not run yet.
+	StateRegistered ModuleState = iota
+	// StateInitialized means Init succeeded and Start has not been launched yet.
+	StateInitialized
+	// StateRunning means the Start goroutine is active.
+	StateRunning
+	// StateExited means Start returned normally.
+	StateExited
+	// StatePanicked means Start terminated with a recovered panic.
+	StatePanicked
+	// StateStopped means Stop has been called during shutdown.
+	StateStopped
+	// StateFailed means Init failed and the module was skipped.
+	StateFailed
+	// StateSuspended means Start was quiesced for low-power mode.
+	StateSuspended
+	// stateLimit is used for boundary checking in the String method.
+	stateLimit
+)
+
+var stateNames = [...]string{
+	"registered",
+	"initialized",
+	"running",
+	"exited",
+	"panicked",
+	"stopped",
+	"failed",
+	"suspended",
+}
+
+// String returns the lowercase name of the state.
+func (s ModuleState) String() string {
+	if s < stateLimit {
+		return stateNames[s]
+	}
+	return "unknown(" + strconv.Itoa(int(s)) + ")"
+}
+
+// ModuleStatus is a point-in-time snapshot of a module's runtime state.
+type ModuleStatus struct {
+	Name     string
+	State    ModuleState
+	Critical bool
+
+	// LastError is the most recent Init or Stop error.
+	LastError error
+	// PanicValue is the value recovered from the last Start panic, if any.
+	PanicValue any
+
+	// StartedAt is the time Start was last launched (zero if never started).
+	StartedAt time.Time
+	// Restarts counts how many times the supervisor relaunched Start.
+	Restarts int
+}
+
+// status builds a snapshot. The caller must hold e.mu.
+func (u *unit) status() ModuleStatus {
+	return ModuleStatus{
+		Name:       u.mod.Name(),
+		State:      u.state,
+		Critical:   isCritical(u.mod),
+		LastError:  u.lastErr,
+		PanicValue: u.panicVal,
+		StartedAt:  u.startedAt,
+		Restarts:   u.restarts,
+	}
+}
+
+// Modules returns the status of every module in boot order.
+// It returns nil before Run has resolved the boot order.
+func (e *Engine) Modules() []ModuleStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.units == nil {
+		return nil
+	}
+	out := make([]ModuleStatus, len(e.units))
+	for i, u := range e.units {
+		out[i] = u.status()
+	}
+	return out
+}
+
+// Module returns the status of a single module by name.
+func (e *Engine) Module(name string) (ModuleStatus, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, u := range e.units {
+		if u.mod.Name() == name {
+			return u.status(), true
+		}
+	}
+	return ModuleStatus{}, false
+}
+
+// setState updates a unit's state under the engine lock.
+func (e *Engine) setState(u *unit, s ModuleState) {
+	e.mu.Lock()
+	u.state = s
+	e.mu.Unlock()
+}
@@ -0,0 +1,266 @@
+// engine/supervisor.go
+package engine
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/magradze/gonnect"
+)
+
+// Fallback backoff values used when a policy leaves them unset.
+const (
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// unit holds the Engine's bookkeeping for a single module.
+type unit struct {
+	mod    gonnect.Module
+	policy gonnect.RestartPolicy
+
+	// cancel stops this module's context only; done is closed when the
+	// supervisor goroutine exits. Both are replaced on every launch.
+	cancel context.CancelFunc
+	done   chan struct{}
+	// stopped is closed when the last Stop call returned.
+	stopped chan struct{}
+	// active is true between launch and Stop.
+	active bool
+
+	// lastBeat is the UnixNano time of the last Heartbeat; stalled is set once a
+	// missed heartbeat has been reported (guarded by Engine.mu).
+	lastBeat atomic.Int64
+	stalled  bool
+
+	// timing holds profiling data (guarded by Engine.mu).
+	timing ModuleTiming
+
+	// history stores the timestamps of recent restarts for budget accounting.
+	history []time.Time
+
+	// Introspection fields, guarded by Engine.mu.
+	state     ModuleState
+	lastErr   error
+	panicVal  any
+	startedAt time.Time
+	restarts  int
+}
+
+func newUnit(m gonnect.Module, fallback gonnect.RestartPolicy) *unit {
+	u := &unit{mod: m, policy: fallback}
+	if s, ok := m.(gonnect.Supervised); ok {
+		u.policy = s.RestartPolicy()
+	}
+	if u.policy.InitialBackoff <= 0 {
+		u.policy.InitialBackoff = defaultInitialBackoff
+	}
+	if u.policy.MaxBackoff < u.policy.InitialBackoff {
+		u.policy.MaxBackoff = defaultMaxBackoff
+	}
+	return u
+}
+
+// allowRestart records a restart attempt and reports whether it fits within the budget.
+func (u *unit) allowRestart(now time.Time) bool {
+	p := u.policy
+	if p.MaxRestarts <= 0 {
+		return true
+	}
+
+	// Drop attempts that fell out of the sliding window (in place, no allocation).
+	if p.Window > 0 {
+		kept := u.history[:0]
+		for _, t := range u.history {
+			if now.Sub(t) < p.Window {
+				kept = append(kept, t)
+			}
+		}
+		u.history = kept
+	}
+
+	if len(u.history) >= p.MaxRestarts {
+		return false
+	}
+	u.history = append(u.history, now)
+	return true
+}
+
+// nextBackoff returns the delay before the next restart. prev is the delay of
+// the previous restart (zero before the first) and ran is how long Start ran.
+// A module that ran healthy for longer than the maximum backoff starts over,
+// so an occasional crash does not inherit an old escalation.
+func (u *unit) nextBackoff(prev, ran time.Duration) time.Duration {
+	p := u.policy
+	if prev <= 0 || ran >= p.MaxBackoff {
+		return p.InitialBackoff
+	}
+	if next := prev * 2; next < p.MaxBackoff {
+		return next
+	}
+	return p.MaxBackoff
+}
+
+// runStart executes mod.Start and reports whether it terminated with a panic.
+func (e *Engine) runStart(ctx context.Context, mod gonnect.Module) (panicked bool, value any) {
+	defer func() {
+		if r := recover(); r != nil {
+			e.log.Error("CRITICAL: Panic recovered in module '%s': %v", mod.Name(), r)
+			panicked, value = true, r
+		}
+	}()
+	mod.Start(ctx)
+	return false, nil
+}
+
+// launch starts the unit's supervisor under a child of the engine context.
+func (e *Engine) launch(u *unit) {
+	e.mu.Lock()
+	ctx, cancel := context.WithCancel(context.WithValue(e.ctx, unitKey{}, u))
+	u.cancel = cancel
+	u.done = make(chan struct{})
+	u.active = true
+	u.state = StateRunning
+	u.timing.Ready = time.Since(e.bootStart)
+	u.timing.Start = 0
+	e.mu.Unlock()
+
+	go e.supervise(ctx, u)
+}
+
+// isActive reports whether a unit has been launched and not yet stopped.
+func (e *Engine) isActive(u *unit) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return u.active
+}
+
+// isRunning reports whether a unit is active and its Start has not returned.
+func (e *Engine) isRunning(u *unit) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return u.active && u.state == StateRunning
+}
+
+// isBusy reports whether a Start or Stop call of the unit's previous run has
+// not returned yet, e.g. because it overran its shutdown budget.
+func (e *Engine) isBusy(u *unit) bool {
+	e.mu.Lock()
+	done, stopped := u.done, u.stopped
+	e.mu.Unlock()
+
+	for _, ch := range [...]chan struct{}{done, stopped} {
+		if ch == nil {
+			continue
+		}
+		select {
+		case <-ch:
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// supervise runs the module's Start and applies its restart policy until ctx is cancelled.
+func (e *Engine) supervise(ctx context.Context, u *unit) {
+	e.mu.Lock()
+	done := u.done
+	e.mu.Unlock()
+	defer close(done)
+
+	name := u.mod.Name()
+	var backoff time.Duration
+
+	for {
+		e.mu.Lock()
+		u.state = StateRunning
+		u.startedAt = time.Now()
+		u.stalled = false
+		e.mu.Unlock()
+		// Every (re)start gets a full heartbeat interval as grace period.
+		u.lastBeat.Store(u.startedAt.UnixNano())
+		e.publishModule(TopicModuleStarted, u)
+
+		panicked, value := e.runStart(ctx, u.mod)
+
+		e.mu.Lock()
+		ran := time.Since(u.startedAt)
+		u.timing.Start = ran
+		if panicked {
+			u.state, u.panicVal = StatePanicked, value
+		} else {
+			u.state = StateExited
+		}
+		e.mu.Unlock()
+
+		if panicked {
+			e.publishModule(TopicModulePanicked, u)
+		} else {
+			e.publishModule(TopicModuleExited, u)
+		}
+
+		// A cancelled context means a regular shutdown, not a crash.
+		if ctx.Err() != nil {
+			return
+		}
+
+		switch u.policy.Mode {
+		case gonnect.RestartAlways:
+		case gonnect.RestartOnPanic:
+			if !panicked {
+				e.log.Debug("Module '%s' finished", name)
+				return
+			}
+		default:
+			if !panicked {
+				e.log.Debug("Module '%s' finished", name)
+			}
+			return
+		}
+
+		if !u.allowRestart(time.Now()) {
+			e.log.Error("Module '%s' exceeded its restart budget (%d in %v)",
+				name, u.policy.MaxRestarts, u.policy.Window)
+			e.publishModule(TopicModuleEscalated, u)
+			e.escalate(name, u.policy.Escalation)
+			return
+		}
+
+		backoff = u.nextBackoff(backoff, ran)
+		e.log.Warn("Restarting module '%s' in %v", name, backoff)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		e.mu.Lock()
+		u.restarts++
+		e.mu.Unlock()
+		e.publishModule(TopicModuleRestarting, u)
+	}
+}
+
+// escalate applies the action configured for a module that exhausted its budget.
+func (e *Engine) escalate(name string, action gonnect.Escalation) {
+	switch action {
+	case gonnect.EscalateReboot:
+		if e.RebootHook != nil {
+			e.log.Error("Escalation: module '%s' requested a reboot", name)
+			e.RebootHook()
+			return
+		}
+		e.log.Warn("Escalation: no RebootHook set, shutting down instead")
+		fallthrough
+	case gonnect.EscalateShutdown:
+		e.log.Error("Escalation: module '%s' is shutting down the engine", name)
+		e.setExit(ExitEscalation, name, ErrRestartBudget)
+		e.Shutdown()
+	default:
+		e.log.Warn("Module '%s' remains stopped", name)
+	}
+}
@@ -0,0 +1,209 @@
+// engine/supervisor_test.go
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/magradze/gonnect"
+)
+
+// supervisedModule is a testModule with a restart policy.
+type supervisedModule struct {
+	testModule
+	policy gonnect.RestartPolicy
+}
+
+func (m *supervisedModule) RestartPolicy() gonnect.RestartPolicy { return m.policy }
+
+// crashing returns a module whose Start panics right away.
+func crashing(name string, p gonnect.RestartPolicy) *supervisedModule {
+	return &supervisedModule{
+		testModule: testModule{name: name, run: func(context.Context) { panic("boom") }},
+		policy:     p,
+	}
+}
+
+func TestNextBackoff(t *testing.T) {
+	u := newUnit(&testModule{name: "m"}, gonnect.RestartPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	})
+	ms := time.Millisecond
+
+	tests := []struct {
+		name      string
+		prev, ran time.Duration
+		want      time.Duration
+	}{
+		{"first restart", 0, 0, 100 * ms},
+		{"doubles", 100 * ms, 10 * ms, 200 * ms},
+		{"doubles again", 400 * ms, 10 * ms, 800 * ms},
+		{"capped", 800 * ms, 10 * ms, time.Second},
+		{"stays capped", time.Second, 10 * ms, time.Second},
+		{"healthy run resets", time.Second, time.Second, 100 * ms},
+		{"short run keeps growing", 200 * ms, 999 * ms, 400 * ms},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := u.nextBackoff(tt.prev, tt.ran); got != tt.want {
+				t.Fatalf("nextBackoff(%v, %v) = %v, want %v", tt.prev, tt.ran, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewUnitPolicyDefaults(t *testing.T) {
+	u := newUnit(&testModule{name: "m"}, gonnect.RestartPolicy{MaxBackoff: time.Millisecond})
+	if u.policy.InitialBackoff != defaultInitialBackoff || u.policy.MaxBackoff != defaultMaxBackoff {
+		t.Fatalf("policy = %+v, want default backoffs", u.policy)
+	}
+
+	s := &supervisedModule{testModule: testModule{name: "s"}, policy: gonnect.RestartPolicy{Mode: gonnect.RestartAlways}}
+	if u := newUnit(s, gonnect.RestartPolicy{}); u.policy.Mode != gonnect.RestartAlways {
+		t.Fatalf("Supervised policy ignored: %+v", u.policy)
+	}
+}
+
+func TestAllowRestartWindow(t *testing.T) {
+	u := newUnit(&testModule{name: "m"}, gonnect.RestartPolicy{MaxRestarts: 2, Window: time.Minute})
+	t0 := time.Unix(1000, 0)
+
+	steps := []struct {
+		at   time.Duration
+		want bool
+	}{
+		{0, true},
+		{10 * time.Second, true},
+		{20 * time.Second, false}, // budget spent within the window
+		{61 * time.Second, true},  // the first attempt has expired
+		{65 * time.Second, false},
+		{71 * time.Second, true}, // the second attempt has expired
+	}
+	for i, s := range steps {
+		if got := u.allowRestart(t0.Add(s.at)); got != s.want {
+			t.Fatalf("step %d (+%v): allowRestart = %v, want %v", i, s.at, got, s.want)
+		}
+	}
+}
+
+func TestAllowRestartLifetime(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	u := newUnit(&testModule{name: "m"}, gonnect.RestartPolicy{MaxRestarts: 1})
+	if !u.allowRestart(now) || u.allowRestart(now.Add(24*time.Hour)) {
+		t.Fatal("a zero Window must count restarts over the module lifetime")
+	}
+
+	u = newUnit(&testModule{name: "m"}, gonnect.RestartPolicy{})
+	for i := 0; i < 100; i++ {
+		if !u.allowRestart(now) {
+			t.Fatalf("unlimited budget refused restart %d", i)
+		}
+	}
+}
+
+func TestRestartOnPanic(t *testing.T) {
+	crashed := make(chan struct{})
+	m := &supervisedModule{policy: gonnect.RestartPolicy{Mode: gonnect.RestartOnPanic, InitialBackoff: time.Millisecond}}
+	m.testModule = testModule{name: "m", run: func(ctx context.Context) {
+		if m.starts.Load() == 1 {
+			close(crashed)
+			panic("boom")
+		}
+		<-ctx.Done()
+	}}
+	e := newTestEngine(t, m)
+	start(t, e, context.Background())
+	defer waitDone(t, e)
+	defer e.Shutdown()
+
+	<-crashed
+	waitFor(t, "restart", func() bool { return m.starts.Load() == 2 && stateOf(e, "m") == StateRunning })
+	if st, _ := e.Module("m"); st.Restarts != 1 || st.PanicValue != "boom" {
+		t.Fatalf("status = %+v", st)
+	}
+}
+
+func TestEscalateShutdown(t *testing.T) {
+	m := crashing("m", gonnect.RestartPolicy{
+		Mode:           gonnect.RestartAlways,
+		InitialBackoff: time.Millisecond,
+		MaxRestarts:    2,
+		Escalation:     gonnect.EscalateShutdown,
+	})
+	e := newTestEngine(t, m)
+	errCh := start(t, e, context.Background())
+
+	var xe *ExitError
+	if err := <-errCh; !errors.As(err, &xe) || xe.Reason != ExitEscalation || xe.Module != "m" {
+		t.Fatalf("RunContext returned %v, want an escalation by 'm'", err)
+	}
+	if !errors.Is(xe, ErrRestartBudget) {
+		t.Fatalf("exit error %v does not wrap ErrRestartBudget", xe)
+	}
+	if n := m.starts.Load(); n != 3 {
+		t.Fatalf("starts = %d, want 3 (first run and 2 restarts)", n)
+	}
+}
+
+func TestEscalateReboot(t *testing.T) {
+	policy := gonnect.RestartPolicy{
+		Mode:           gonnect.RestartAlways,
+		InitialBackoff: time.Millisecond,
+		MaxRestarts:    1,
+		Escalation:     gonnect.EscalateReboot,
+	}
+
+	t.Run("hook", func(t *testing.T) {
+		rebooted := make(chan struct{})
+		e := newTestEngine(t, crashing("m", policy))
+		e.RebootHook = func() { close(rebooted) }
+		start(t, e, context.Background())
+		defer waitDone(t, e)
+		defer e.Shutdown()
+
+		select {
+		case <-rebooted:
+		case <-time.After(time.Second):
+			t.Fatal("RebootHook was not called")
+		}
+		if err := e.exitErr(); err != nil {
+			t.Fatalf("a reboot must not record an exit, got %v", err)
+		}
+	})
+
+	t.Run("no hook", func(t *testing.T) {
+		e := newTestEngine(t, crashing("m", policy))
+		errCh := start(t, e, context.Background())
+
+		var xe *ExitError
+		if err := <-errCh; !errors.As(err, &xe) || xe.Reason != ExitEscalation {
+			t.Fatalf("RunContext returned %v, want a shutdown escalation", err)
+		}
+	})
+}
+
+func TestEscalateNone(t *testing.T) {
+	m := crashing("m", gonnect.RestartPolicy{
+		Mode:           gonnect.RestartAlways,
+		InitialBackoff: time.Millisecond,
+		MaxRestarts:    1,
+	})
+	other := &testModule{name: "other"}
+	e := newTestEngine(t, m, other)
+	start(t, e, context.Background())
+	defer waitDone(t, e)
+	defer e.Shutdown()
+
+	waitFor(t, "budget exhausted", func() bool { return m.starts.Load() == 2 && stateOf(e, "m") == StatePanicked })
+	time.Sleep(10 * time.Millisecond)
+	if n := m.starts.Load(); n != 2 {
+		t.Fatalf("starts = %d after the budget was spent, want 2", n)
+	}
+	if stateOf(e, "other") != StateRunning {
+		t.Fatalf("other module state = %s, want running", stateOf(e, "other"))
+	}
+}
@@ -0,0 +1,174 @@
+// engine/watchdog.go
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/magradze/gonnect"
+)
+
+// DefaultWatchdogTimeout is the hardware timeout used when Engine.WatchdogTimeout is zero.
+const DefaultWatchdogTimeout = 8 * time.Second
+
+// TopicModuleStalled is published when a watched module misses its heartbeat.
+const TopicModuleStalled = "system/module/stalled"
+
+// Watchdog abstracts the hardware watchdog timer.
+// On TinyGo targets, drivers/watchdog wraps machine.Watchdog; on a host, use SoftWatchdog.
+type Watchdog interface {
+	// Start arms the watchdog. The device resets if Feed is not called within timeout.
+	Start(timeout time.Duration) error
+	// Feed restarts the countdown.
+	Feed()
+}
+
+// unitKey is the context key under which a module's unit is stored.
+type unitKey struct{}
+
+// Heartbeat marks the calling module as healthy. It must be called with the
+// context passed to Start; calls with any other context are ignored.
+func Heartbeat(ctx context.Context) {
+	if u, ok := ctx.Value(unitKey{}).(*unit); ok {
+		u.lastBeat.Store(time.Now().UnixNano())
+	}
+}
+
+// watchInterval returns the module's heartbeat interval, or zero if it is not watched.
+func watchInterval(m gonnect.Module) time.Duration {
+	if w, ok := m.(gonnect.Watched); ok {
+		return w.HeartbeatInterval()
+	}
+	return 0
+}
+
+// watchdogTimeout returns the configured hardware timeout.
+func (e *Engine) watchdogTimeout() time.Duration {
+	if e.WatchdogTimeout > 0 {
+		return e.WatchdogTimeout
+	}
+	return DefaultWatchdogTimeout
+}
+
+// runWatchdog arms the hardware watchdog and feeds it while all watched modules are healthy.
+// It returns when stop is closed. During shutdown (module context cancelled) it feeds
+// unconditionally, leaving the per-module shutdown budgets in charge.
+func (e *Engine) runWatchdog(ctx context.Context, stop <-chan struct{}) {
+	timeout := e.watchdogTimeout()
+	if err := e.Watchdog.Start(timeout); err != nil {
+		e.log.Error("Watchdog: failed to start: %v", err)
+		return
+	}
+	e.log.Info("Watchdog armed (%v)", timeout)
+
+	// Feed well within the hardware window.
+	ticker := time.NewTicker(timeout / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if ctx.Err() != nil || e.healthy(time.Now()) {
+				e.Watchdog.Feed()
+			}
+		}
+	}
+}
+
+// healthy reports whether every running watched module has checked in recently.
+// A module blocked in Suspend is exempt. A stalled module is logged and
+// published once per stall.
+func (e *Engine) healthy(now time.Time) bool {
+	unhealthy := 0
+	var newly []*unit
+
+	e.mu.Lock()
+	for _, u := range e.units {
+		interval := watchInterval(u.mod)
+		if interval <= 0 || !u.active || u.state != StateRunning || u == e.suspender {
+			continue
+		}
+		if now.Sub(time.Unix(0, u.lastBeat.Load())) <= interval {
+			u.stalled = false
+			continue
+		}
+		unhealthy++
+		if !u.stalled {
+			u.stalled = true
+			newly = append(newly, u)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, u := range newly {
+		since := now.Sub(time.Unix(0, u.lastBeat.Load()))
+		e.log.Error("Watchdog: module '%s' stalled (no heartbeat for %v); reset imminent", u.mod.Name(), since)
+		e.publishModule(TopicModuleStalled, u)
+	}
+	return unhealthy == 0
+}
+
+// SoftWatchdog is a software Watchdog for host builds and tests.
+// Instead of resetting the CPU, it calls OnExpire when it is not fed in time.
+type SoftWatchdog struct {
+	// OnExpire is called from a timer goroutine when the watchdog fires.
+	OnExpire func()
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	timeout time.Duration
+	feeds   int
+	expired bool
+}
+
+// Start arms the software watchdog.
+func (w *SoftWatchdog) Start(timeout time.Duration) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.timeout = timeout
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(timeout, w.fire)
+	return nil
+}
+
+// Feed restarts the countdown.
+func (w *SoftWatchdog) Feed() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.feeds++
+	if w.timer != nil {
+		w.timer.Reset(w.timeout)
+	}
+}
+
+// Feeds returns how many times the watchdog was fed.
+func (w *SoftWatchdog) Feeds() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.feeds
+}
+
+// Expired reports whether the watchdog has fired.
+func (w *SoftWatchdog) Expired() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.expired
+}
+
+func (w *SoftWatchdog) fire() {
+	w.mu.Lock()
+	w.expired = true
+	fn := w.OnExpire
+	w.mu.Unlock()
+
+	if fn != nil {
+		fn()
+	}
+}
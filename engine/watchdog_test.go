@@ -0,0 +1,124 @@
+// engine/watchdog_test.go
+package engine
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/magradze/gonnect"
+)
+
+// watchedModule is a testModule supervised by the watchdog.
+type watchedModule struct {
+	testModule
+	interval time.Duration
+}
+
+func (m *watchedModule) HeartbeatInterval() time.Duration { return m.interval }
+
+// beating returns a watched module that calls Heartbeat every interval/4.
+func beating(name string, interval time.Duration) *watchedModule {
+	return &watchedModule{
+		testModule: testModule{name: name, run: func(ctx context.Context) {
+			ticker := time.NewTicker(interval / 4)
+			defer ticker.Stop()
+			for {
+				Heartbeat(ctx)
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}},
+		interval: interval,
+	}
+}
+
+func TestHeartbeatContext(t *testing.T) {
+	u := newUnit(&watchedModule{testModule: testModule{name: "m"}, interval: time.Second}, gonnect.RestartPolicy{})
+
+	Heartbeat(context.Background())
+	if u.lastBeat.Load() != 0 {
+		t.Fatal("Heartbeat with a foreign context updated the unit")
+	}
+	Heartbeat(context.WithValue(context.Background(), unitKey{}, u))
+	if u.lastBeat.Load() == 0 {
+		t.Fatal("Heartbeat with the module context was ignored")
+	}
+}
+
+func TestHealthy(t *testing.T) {
+	e := newTestEngine(t)
+	stalled := e.rt.Bus.Subscribe(TopicModuleStalled)
+
+	now := time.Now()
+	mk := func(name string, interval, age time.Duration, state ModuleState) *unit {
+		u := newUnit(&watchedModule{testModule: testModule{name: name}, interval: interval}, gonnect.RestartPolicy{})
+		u.active, u.state = true, state
+		u.lastBeat.Store(now.Add(-age).UnixNano())
+		return u
+	}
+	fresh := mk("fresh", time.Second, 100*time.Millisecond, StateRunning)
+	late := mk("late", time.Second, 2*time.Second, StateRunning)
+	exited := mk("exited", time.Second, time.Hour, StateExited)
+	unwatched := mk("unwatched", 0, time.Hour, StateRunning)
+
+	e.units = []*unit{fresh, exited, unwatched}
+	if !e.healthy(now) {
+		t.Fatal("only fresh, exited and unwatched modules, want healthy")
+	}
+
+	e.units = append(e.units, late)
+	if e.healthy(now) || e.healthy(now) {
+		t.Fatal("late module, want unhealthy")
+	}
+	if len(stalled) != 1 {
+		t.Fatalf("%d stall events, want exactly 1 per stall", len(stalled))
+	}
+	if evt := <-stalled; evt.Payload.(ModuleStatus).Name != "late" {
+		t.Fatalf("stall event for %v", evt.Payload)
+	}
+
+	late.lastBeat.Store(now.UnixNano())
+	if !e.healthy(now) {
+		t.Fatal("late module checked in again, want healthy")
+	}
+}
+
+func TestWatchdogFedWhileHealthy(t *testing.T) {
+	wd := &SoftWatchdog{}
+	e := newTestEngine(t, beating("m", 20*time.Millisecond))
+	e.Watchdog = wd
+	e.WatchdogTimeout = 40 * time.Millisecond
+	start(t, e, context.Background())
+
+	time.Sleep(200 * time.Millisecond)
+	e.Shutdown()
+	waitDone(t, e)
+
+	if wd.Expired() || wd.Feeds() == 0 {
+		t.Fatalf("expired=%v feeds=%d, want a fed watchdog", wd.Expired(), wd.Feeds())
+	}
+}
+
+func TestWatchdogExpiresOnStall(t *testing.T) {
+	fired := make(chan struct{})
+	var once sync.Once
+	wd := &SoftWatchdog{OnExpire: func() { once.Do(func() { close(fired) }) }}
+	stuck := &watchedModule{testModule: testModule{name: "stuck"}, interval: 10 * time.Millisecond}
+	e := newTestEngine(t, stuck)
+	e.Watchdog = wd
+	e.WatchdogTimeout = 40 * time.Millisecond
+	start(t, e, context.Background())
+	defer waitDone(t, e)
+	defer e.Shutdown()
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("watchdog was fed although the module never sent a heartbeat")
+	}
+}
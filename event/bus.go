@@ -2,7 +2,9 @@
 package event
 
 import (
+	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/magradze/gonnect/pkg/logger"
@@ -18,24 +20,58 @@ type Event struct {
 	Payload   interface{}
 	Source    string
 	Timestamp int64
+	// Retained is set on events published with PublishRetained, both live and
+	// when the stored last value is replayed to a new subscriber.
+	Retained bool
+	// Priority selects the subscriber lane (see WithHighLane).
+	Priority Priority
+	// ReplyTo and CorrelationID are set on requests and their replies (see Request).
+	ReplyTo       string
+	CorrelationID uint64
 }
 
 // Bus manages the subscription and publication of events.
+// Subscriptions are stored in a topic trie, so filters may use MQTT-style
+// wildcards ("sensors/+/temperature", "system/#").
 type Bus struct {
-	mu          sync.Mutex // Changed from RWMutex to Mutex for stability
-	subscribers map[string][]chan Event
+	mu   sync.Mutex // Changed from RWMutex to Mutex for stability
+	root node
+
+	// scratch is reused by Publish to collect matching subscribers without
+	// allocating on every call. Guarded by mu.
+	scratch []*Subscription
+
+	// retained holds the last value of retained topics. Lazily allocated.
+	retained map[string]Event
+
+	// topics holds per-topic counters, guarded by mu; the counters themselves are atomic.
+	topics    map[string]*topicCounters
+	untracked uint64
+
+	// sched runs PublishAfter and PublishEvery timers.
+	sched scheduler
+
+	// pump drains ISR sources.
+	pump pump
+
+	// chain is replaced as a whole by Use, so Publish reads it without locking.
+	chain atomic.Pointer[[]Middleware]
 }
 
-// defaultBus is the global instance.
+// defaultBus is the global instance used by the package-level functions.
 var defaultBus = &Bus{}
 
-func (b *Bus) ensureInit() {
-	if b.subscribers == nil {
-		b.subscribers = make(map[string][]chan Event)
-	}
+// NewBus creates an isolated event bus, e.g. for a second Engine or a test.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Default returns the process-wide event bus.
+func Default() *Bus {
+	return defaultBus
 }
 
-// Subscribe registers a listener for a specific topic.
+// Subscribe registers a listener for a topic filter on the default bus.
 func Subscribe(topic string) <-chan Event {
 	return defaultBus.Subscribe(topic)
 }
@@ -46,57 +82,118 @@ func Publish(topic string, value int64, payload interface{}, source string) int
 }
 
 // Subscribe (instance method).
+// The topic may be an exact topic or a filter with "+" and "#" wildcards.
+// Malformed wildcards (e.g. "a/#/b", "sens+") are matched literally.
+// The listener lives for the lifetime of the bus; use SubscribeContext
+// for a subscription that can be removed.
 func (b *Bus) Subscribe(topic string) <-chan Event {
-	b.mu.Lock()
-	defer b.mu.Unlock()
+	return b.SubscribeContext(context.Background(), topic).C
+}
 
-	b.ensureInit()
+// Publish (instance method).
+// It never blocks unless a matching subscriber uses the Block policy; such
+// deliveries happen after the bus lock is released, bounded by their timeout.
+// It returns the number of subscribers that lost an event.
+func (b *Bus) Publish(topic string, value int64, payload interface{}, source string) int {
+	return b.publish(Event{
+		Topic:   topic,
+		Value:   value,
+		Payload: payload,
+		Source:  source,
+	}, false)
+}
 
-	ch := make(chan Event, DefaultBufferSize)
+// PublishEvent publishes a prepared event, e.g. one read back from a recording.
+// Priority, ReplyTo and CorrelationID are kept; Retained publishes it as
+// with PublishRetained. The timestamp is set by the bus.
+func (b *Bus) PublishEvent(evt Event) int {
+	return b.publish(evt, evt.Retained)
+}
 
-	if _, ok := b.subscribers[topic]; !ok {
-		b.subscribers[topic] = make([]chan Event, 0, 2)
+// publish stamps a prepared event, runs the middleware chain and fans it out.
+// With retain set, the event (as rewritten by the middleware) becomes the topic's last value.
+func (b *Bus) publish(evt Event, retain bool) int {
+	evt.Timestamp = time.Now().UnixNano()
+	evt.Retained = retain
+	if !b.intercept(&evt) {
+		return 0
 	}
 
-	b.subscribers[topic] = append(b.subscribers[topic], ch)
-	logger.Debug("EventBus: New subscriber for '%s'", topic)
-
-	return ch
-}
-
-// Publish (instance method) - Non-blocking.
-func (b *Bus) Publish(topic string, value int64, payload interface{}, source string) int {
 	b.mu.Lock()
-	defer b.mu.Unlock()
 
-	if b.subscribers == nil {
-		return 0
+	if retain {
+		b.retain(evt)
 	}
 
-	subscribers, found := b.subscribers[topic]
-	if !found || len(subscribers) == 0 {
+	subscribers := b.root.match(evt.Topic, b.scratch[:0])
+	b.scratch = subscribers
+	return fanOut(b, evt.Topic, evt.Timestamp, subscribers, evt, (*Subscription).send)
+}
+
+// fanOut hands v to every matched subscriber through send and updates the
+// topic counters. It is generic so that typed topics can queue their values
+// without boxing them into an Event. The caller must hold b.mu; it is released.
+func fanOut[V any](b *Bus, topic string, ts int64, subscribers []*Subscription, v V, send func(*Subscription, V) bool) int {
+	if len(subscribers) == 0 {
+		b.mu.Unlock()
 		return 0
 	}
+	matched := len(subscribers)
 
-	evt := Event{
-		Topic:     topic,
-		Value:     value,
-		Payload:   payload,
-		Source:    source,
-		Timestamp: time.Now().UnixNano(),
+	tc := b.counters(topic)
+	if tc != nil {
+		tc.published.Add(1)
+		tc.last.Store(ts)
 	}
 
 	dropped := 0
+	// Block subscribers are served after unlocking. A small stack array
+	// covers the common case without allocating.
+	var blockBuf [4]*Subscription
+	blocking := blockBuf[:0]
+
+	for _, sub := range subscribers {
+		if sub.policy == Block {
+			blocking = append(blocking, sub)
+			continue
+		}
+		if !send(sub, v) {
+			dropped++
+		}
+	}
+
+	// Do not keep subscriber references alive through the scratch buffer.
+	clear(subscribers)
+	b.mu.Unlock()
 
-	for _, ch := range subscribers {
-		select {
-		case ch <- evt:
-			// Delivered
-		default:
+	for _, sub := range blocking {
+		if !send(sub, v) {
 			dropped++
-			logger.Warn("EventBus: Dropped '%s' from '%s'", topic, source)
 		}
 	}
 
+	if tc != nil {
+		tc.delivered.Add(uint64(matched - dropped))
+		tc.dropped.Add(uint64(dropped))
+	}
 	return dropped
-}
\ No newline at end of file
+}
+
+// send delivers under the subscription's send lock and logs losses.
+// Typed subscriptions skip events whose payload has another type.
+func (s *Subscription) send(evt Event) bool {
+	if !s.accepts(evt) {
+		logger.Warn("EventBus: Skipped '%s' from '%s' for typed '%s': payload is %T", evt.Topic, evt.Source, s.filter, evt.Payload)
+		return true
+	}
+
+	s.sendMu.Lock()
+	ok := s.deliver(evt)
+	s.record(evt.Priority, ok)
+	s.sendMu.Unlock()
+
+	if !ok {
+		s.warnDrop(evt.Topic, evt.Source)
+	}
+	return ok
+}
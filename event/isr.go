@@ -0,0 +1,308 @@
+// event/isr.go
+package event
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Interrupt handlers cannot wake a goroutine safely (no channel sends or
+// locks in ISR context), so the bus pump has to poll the rings. It polls
+// adaptively: every minPumpInterval while edges keep arriving, backing off
+// to the pump interval (DefaultPumpInterval) when idle. The first event after
+// a quiet period therefore waits up to one pump interval, and an open source
+// costs one wake-up per interval for as long as it stays open; raise the
+// interval to save wake-ups, lower it for latency.
+//
+// With PumpOnDemand there is no idle polling at all: the pump parks once the
+// rings stay empty and only runs again after Flush or ResumePump. Use it when
+// something else already knows that an interrupt fired, e.g. a module woken
+// from Suspend by a pin, and have that module call Flush. Flush drains the
+// rings immediately from any goroutine, and the pump stops entirely while
+// paused (see PausePump).
+
+// DefaultPumpInterval is the idle polling interval of the bus pump.
+const DefaultPumpInterval = 50 * time.Millisecond
+
+// PumpOnDemand, passed to SetPumpInterval, disables idle polling.
+const PumpOnDemand time.Duration = -1
+
+// minPumpInterval is the polling interval while events are flowing.
+const minPumpInterval = time.Millisecond
+
+// ISRSource is a lock-free single-producer ring that lets an interrupt handler
+// feed the bus. Publish takes no lock, does not allocate and does not read the
+// clock; a pump goroutine drains the ring, stamps the events and publishes them.
+// The topics are fixed when the source is created and referenced by index.
+//
+// Each source must have a single producer: one interrupt handler (or one goroutine).
+type ISRSource struct {
+	bus    *Bus
+	source string
+	topics []string
+
+	buf  []isrEntry
+	mask uint32
+	head atomic.Uint32 // Written by the producer only.
+	tail atomic.Uint32 // Written by the consumer only (pump.drainMu).
+
+	dropped atomic.Uint32
+}
+
+// isrEntry is one queued event: a topic index and a value.
+type isrEntry struct {
+	topic uint8
+	value int64
+}
+
+// pump drains the ISR sources of a bus. The zero value is ready to use.
+type pump struct {
+	mu       sync.Mutex
+	sources  []*ISRSource
+	running  bool
+	paused   bool
+	interval time.Duration
+	wake     chan struct{}
+
+	// drainMu keeps a single consumer per ring (pump goroutine or Flush).
+	drainMu sync.Mutex
+}
+
+// NewISRSource creates an ISR source on the default bus.
+func NewISRSource(source string, size int, topics ...string) *ISRSource {
+	return defaultBus.NewISRSource(source, size, topics...)
+}
+
+// NewISRSource creates an ISR source publishing on the given topics (at most 256),
+// with room for size pending events (rounded up to a power of two).
+// Create it outside the interrupt handler, typically in Module.Init, and
+// Close it in Stop. The pump goroutine runs while any source is open
+// and the pump is not paused.
+//
+// Usage:
+//
+//	src := event.NewISRSource(ModuleName, 8, "input/button/press")
+//	pin.SetInterrupt(machine.PinFalling, func(machine.Pin) { src.Publish(0, 1) })
+func (b *Bus) NewISRSource(source string, size int, topics ...string) *ISRSource {
+	if len(topics) == 0 || len(topics) > 256 {
+		panic("event: an ISR source needs 1 to 256 topics")
+	}
+
+	n := 1
+	for n < size {
+		n <<= 1
+	}
+
+	s := &ISRSource{
+		bus:    b,
+		source: source,
+		topics: topics,
+		buf:    make([]isrEntry, n),
+		mask:   uint32(n - 1),
+	}
+	b.pump.add(s)
+	return s
+}
+
+// SetPumpInterval changes the idle polling interval of the pump (default
+// DefaultPumpInterval). It bounds the latency of the first interrupt after a
+// quiet period; a longer interval means fewer wake-ups. PumpOnDemand stops
+// idle polling: queued events then wait for the next Flush or ResumePump.
+func (b *Bus) SetPumpInterval(d time.Duration) {
+	b.pump.mu.Lock()
+	defer b.pump.mu.Unlock()
+
+	if d > 0 || d == PumpOnDemand {
+		b.pump.interval = d
+		b.pump.notify()
+	}
+}
+
+// Flush publishes every event queued by ISR sources right away, e.g. from a
+// module that knows an interrupt just fired. It also wakes a parked pump
+// (see PumpOnDemand) so that edges following the first one, such as contact
+// bounce, are picked up without another Flush. It is not ISR-safe.
+func (b *Bus) Flush() {
+	b.pump.mu.Lock()
+	batch := append([]*ISRSource(nil), b.pump.sources...)
+	b.pump.notify()
+	b.pump.mu.Unlock()
+
+	b.pump.drain(batch)
+}
+
+// PausePump stops polling ISR sources, e.g. before entering a low-power mode.
+// Interrupts keep queueing into the rings (up to their size) until ResumePump.
+func (b *Bus) PausePump() {
+	b.pump.mu.Lock()
+	defer b.pump.mu.Unlock()
+
+	b.pump.paused = true
+	b.pump.notify()
+}
+
+// ResumePump restarts polling and publishes what was queued meanwhile.
+func (b *Bus) ResumePump() {
+	b.pump.mu.Lock()
+	defer b.pump.mu.Unlock()
+
+	b.pump.paused = false
+	b.pump.start()
+	b.pump.notify()
+}
+
+// Publish queues an event on the topic with the given index.
+// It is safe to call from an interrupt handler. It returns false when the
+// ring is full or the index is unknown; such events are counted in Dropped.
+func (s *ISRSource) Publish(topic int, value int64) bool {
+	head := s.head.Load()
+	if uint(topic) >= uint(len(s.topics)) || head-s.tail.Load() > s.mask {
+		s.dropped.Add(1)
+		return false
+	}
+
+	s.buf[head&s.mask] = isrEntry{topic: uint8(topic), value: value}
+	// The atomic store publishes the entry to the pump.
+	s.head.Store(head + 1)
+	return true
+}
+
+// Dropped returns the number of events lost because the ring was full.
+func (s *ISRSource) Dropped() uint32 {
+	return s.dropped.Load()
+}
+
+// Close detaches the source from the pump. Disable the interrupt first;
+// events still queued are discarded.
+func (s *ISRSource) Close() {
+	s.bus.pump.remove(s)
+}
+
+// drain publishes every queued event and returns how many there were.
+// The caller must hold pump.drainMu.
+func (s *ISRSource) drain() int {
+	tail := s.tail.Load()
+	head := s.head.Load()
+	n := int(head - tail)
+
+	for ; tail != head; tail++ {
+		e := s.buf[tail&s.mask]
+		// Free the slot before publishing so the producer can reuse it.
+		s.tail.Store(tail + 1)
+		s.bus.publish(Event{
+			Topic:  s.topics[e.topic],
+			Value:  e.value,
+			Source: s.source,
+		}, false)
+	}
+	return n
+}
+
+// add registers a source and starts the pump goroutine if needed.
+func (p *pump) add(s *ISRSource) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sources = append(p.sources, s)
+	p.start()
+}
+
+// start launches the pump goroutine unless it is running or paused.
+// The caller must hold p.mu.
+func (p *pump) start() {
+	if p.running || p.paused || len(p.sources) == 0 {
+		return
+	}
+	if p.wake == nil {
+		p.wake = make(chan struct{}, 1)
+	}
+	p.running = true
+	go p.run()
+}
+
+// notify interrupts the pump's wait. The caller must hold p.mu.
+func (p *pump) notify() {
+	if p.wake == nil {
+		return
+	}
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// remove unregisters a source; the pump exits with the last one.
+func (p *pump) remove(s *ISRSource) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, src := range p.sources {
+		if src == s {
+			p.sources = append(p.sources[:i], p.sources[i+1:]...)
+			p.notify()
+			return
+		}
+	}
+}
+
+// drain empties the given sources and returns the number of events published.
+func (p *pump) drain(batch []*ISRSource) int {
+	p.drainMu.Lock()
+	defer p.drainMu.Unlock()
+
+	n := 0
+	for _, s := range batch {
+		n += s.drain()
+	}
+	return n
+}
+
+// run polls all sources until none is left or the pump is paused.
+// In PumpOnDemand mode it parks once the backoff reaches DefaultPumpInterval
+// without finding events, until notify wakes it.
+func (p *pump) run() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	var batch []*ISRSource
+	wait := minPumpInterval
+	for {
+		p.mu.Lock()
+		if len(p.sources) == 0 || p.paused {
+			p.running = false
+			p.mu.Unlock()
+			return
+		}
+		batch = append(batch[:0], p.sources...)
+		idle := p.interval
+		p.mu.Unlock()
+
+		onDemand := idle == PumpOnDemand
+		if idle <= 0 {
+			idle = DefaultPumpInterval
+		}
+		n := p.drain(batch)
+		clear(batch)
+
+		switch {
+		case n > 0:
+			wait = minPumpInterval
+		case onDemand && wait >= idle:
+			<-p.wake
+			wait = minPumpInterval
+			continue
+		default:
+			if wait *= 2; wait > idle {
+				wait = idle
+			}
+		}
+
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-p.wake:
+			timer.Stop()
+		}
+	}
+}
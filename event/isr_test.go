@@ -0,0 +1,128 @@
+// event/isr_test.go
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestISRSourcePublish(t *testing.T) {
+	b := NewBus()
+	b.SetPumpInterval(5 * time.Millisecond)
+	sub := b.SubscribeContext(context.Background(), "button/#")
+	defer sub.Unsubscribe()
+
+	src := b.NewISRSource("button", 4, "button/press", "button/release")
+	defer src.Close()
+
+	if !src.Publish(0, 1) || !src.Publish(1, 0) {
+		t.Fatal("Publish failed on an empty ring")
+	}
+	if src.Publish(2, 0) {
+		t.Fatal("Publish accepted an unknown topic index")
+	}
+
+	for i, want := range []string{"button/press", "button/release"} {
+		select {
+		case evt := <-sub.C:
+			if evt.Topic != want || evt.Source != "button" || evt.Timestamp == 0 {
+				t.Fatalf("event %d = %+v", i, evt)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("pump did not deliver")
+		}
+	}
+}
+
+func TestISRSourceFullRing(t *testing.T) {
+	b := NewBus()
+	b.PausePump()
+	src := b.NewISRSource("button", 4, "button/press")
+	defer src.Close()
+
+	for i := 0; i < 6; i++ {
+		src.Publish(0, int64(i))
+	}
+	if src.Dropped() != 2 {
+		t.Fatalf("Dropped = %d, want 2", src.Dropped())
+	}
+}
+
+func TestPausePumpAndFlush(t *testing.T) {
+	b := NewBus()
+	sub := b.SubscribeContext(context.Background(), "#")
+	defer sub.Unsubscribe()
+
+	src := b.NewISRSource("button", 4, "button/press")
+	defer src.Close()
+
+	b.PausePump()
+	src.Publish(0, 1)
+	time.Sleep(2 * DefaultPumpInterval)
+	if len(sub.C) != 0 {
+		t.Fatal("paused pump delivered an event")
+	}
+
+	b.Flush()
+	if len(sub.C) != 1 {
+		t.Fatal("Flush did not deliver the queued event")
+	}
+
+	src.Publish(0, 2)
+	b.ResumePump()
+	select {
+	case <-sub.C:
+	case <-time.After(time.Second):
+		t.Fatal("resumed pump did not deliver")
+	}
+}
+
+func TestISRSourcePublishDoesNotAllocate(t *testing.T) {
+	b := NewBus()
+	b.PausePump()
+	src := b.NewISRSource("button", 4, "button/press")
+	defer src.Close()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		src.Publish(0, 1)
+	})
+	if allocs != 0 {
+		t.Fatalf("Publish allocates %.1f times per call", allocs)
+	}
+}
+
+func TestPumpOnDemand(t *testing.T) {
+	b := NewBus()
+	b.SetPumpInterval(PumpOnDemand)
+	sub := b.SubscribeContext(context.Background(), "#")
+	defer sub.Unsubscribe()
+
+	src := b.NewISRSource("button", 4, "button/press")
+	defer src.Close()
+
+	// Let the pump back off and park.
+	time.Sleep(5 * DefaultPumpInterval)
+	src.Publish(0, 1)
+	time.Sleep(2 * DefaultPumpInterval)
+	if len(sub.C) != 0 {
+		t.Fatal("parked pump polled the ring")
+	}
+
+	b.Flush()
+	if len(sub.C) != 1 {
+		t.Fatal("Flush did not deliver the queued event")
+	}
+	<-sub.C
+
+	// Right after a Flush the pump polls again and catches follow-up edges.
+	src.Publish(0, 2)
+	select {
+	case evt := <-sub.C:
+		if evt.Value != 2 {
+			t.Fatalf("got %d, want the follow-up edge", evt.Value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("follow-up edge not delivered after Flush")
+	}
+}
@@ -0,0 +1,56 @@
+// event/middleware.go
+package event
+
+import "github.com/magradze/gonnect/pkg/logger"
+
+// Middleware intercepts every event before fan-out. It may inspect the event
+// (tracing, metrics), modify it in place (payload normalization, topic
+// remapping) or veto it by returning an error (ACLs, validation).
+// Middleware runs on the publisher's goroutine without the bus lock held,
+// so it may publish itself, but it must be fast and must not block.
+type Middleware func(evt *Event) error
+
+// Use appends middleware to the default bus.
+func Use(mw ...Middleware) {
+	defaultBus.Use(mw...)
+}
+
+// Use appends middleware to the chain. Middleware runs in registration order
+// and the chain stops at the first veto. Register it during boot, before
+// modules start publishing.
+//
+// Usage (per-source ACL):
+//
+//	bus.Use(func(evt *event.Event) error {
+//		if strings.HasPrefix(evt.Topic, "app/command/") && evt.Source != "console" {
+//			return errors.New("not allowed")
+//		}
+//		return nil
+//	})
+func (b *Bus) Use(mw ...Middleware) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var chain []Middleware
+	if cur := b.chain.Load(); cur != nil {
+		chain = append(chain, *cur...)
+	}
+	chain = append(chain, mw...)
+	b.chain.Store(&chain)
+}
+
+// intercept runs the chain and reports whether the event may be delivered.
+func (b *Bus) intercept(evt *Event) bool {
+	chain := b.chain.Load()
+	if chain == nil {
+		return true
+	}
+
+	for _, mw := range *chain {
+		if err := mw(evt); err != nil {
+			logger.Warn("EventBus: '%s' from '%s' vetoed: %v", evt.Topic, evt.Source, err)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,155 @@
+// event/policy.go
+package event
+
+import (
+	"time"
+
+	"github.com/magradze/gonnect/pkg/logger"
+)
+
+// DefaultBlockTimeout bounds a Block delivery when no timeout is given.
+const DefaultBlockTimeout = 100 * time.Millisecond
+
+// Policy selects what Publish does when a subscriber's buffer is full.
+type Policy uint8
+
+const (
+	// DropNewest discards the event being published (default).
+	DropNewest Policy = iota
+	// DropOldest discards the oldest queued event to make room (ring semantics).
+	DropOldest
+	// KeepLatest keeps only the most recent event (conflation). The buffer size
+	// of each lane is forced to 1. Replacing a queued event is by design and
+	// is not counted or logged as a drop.
+	KeepLatest
+	// Block waits for buffer space up to a timeout, then drops the event.
+	// The publisher is delayed, so reserve it for low-rate, must-not-miss consumers.
+	Block
+)
+
+// Option configures a subscription.
+type Option func(*Subscription)
+
+// WithBuffer sets the channel capacity (default DefaultBufferSize).
+func WithBuffer(size int) Option {
+	return func(s *Subscription) {
+		if size > 0 {
+			s.size = size
+		}
+	}
+}
+
+// WithPolicy sets the backpressure policy.
+func WithPolicy(p Policy) Option {
+	return func(s *Subscription) {
+		s.policy = p
+	}
+}
+
+// WithBlockTimeout selects the Block policy with the given timeout.
+func WithBlockTimeout(d time.Duration) Option {
+	return func(s *Subscription) {
+		s.policy = Block
+		s.timeout = d
+	}
+}
+
+// deliver hands an event to the subscriber according to its policy.
+// It reports whether the event was queued without losing any event.
+// The caller must hold s.sendMu.
+func (s *Subscription) deliver(evt Event) bool {
+	if s.closed {
+		return false
+	}
+	if s.sink != nil {
+		return s.sink.put(evt, s.policy, s.timeout)
+	}
+	return put(s.lane(evt), evt, s.policy, s.timeout)
+}
+
+// offer is the non-waiting part of deliver: Block behaves like DropNewest.
+// The caller must hold s.sendMu.
+func (s *Subscription) offer(evt Event) bool {
+	if s.closed {
+		return false
+	}
+	if s.sink != nil {
+		return s.sink.put(evt, s.policy, 0)
+	}
+	return offer(s.lane(evt), evt, s.policy)
+}
+
+// put queues v on ch following policy p, waiting up to timeout under Block.
+// It reports whether v was queued without losing any value.
+// Only senders holding the subscription's sendMu may fill ch.
+func put[E any](ch chan E, v E, p Policy, timeout time.Duration) bool {
+	if p != Block || timeout <= 0 {
+		return offer(ch, v, p)
+	}
+
+	select {
+	case ch <- v:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case ch <- v:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// offer queues v on ch without waiting: Block behaves like DropNewest.
+func offer[E any](ch chan E, v E, p Policy) bool {
+	select {
+	case ch <- v:
+		return true
+	default:
+	}
+
+	switch p {
+	case DropOldest, KeepLatest:
+		// Only senders holding sendMu fill the channel, so after evicting one
+		// entry (or the consumer taking it) the send below cannot block.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- v
+		return p == KeepLatest
+	}
+	return false
+}
+
+// dropWarnInterval limits drop warnings to one per subscription per interval.
+const dropWarnInterval = time.Second
+
+// warnDrop logs an event lost to a full subscriber. A slow consumer would
+// otherwise flood the log, so it warns at most once per dropWarnInterval and
+// reports how many drops were not logged; Stats has the exact count.
+func (s *Subscription) warnDrop(topic, source string) {
+	skipped, ok := s.warnDue(time.Now().UnixNano())
+	if !ok {
+		return
+	}
+	if skipped > 0 {
+		logger.Warn("EventBus: Dropped '%s' from '%s' for '%s' (%d more not logged)", topic, source, s.filter, skipped)
+		return
+	}
+	logger.Warn("EventBus: Dropped '%s' from '%s' for '%s'", topic, source, s.filter)
+}
+
+// warnDue reports whether a drop at time now (UnixNano) should be logged, and
+// how many drops were suppressed since the last warning.
+func (s *Subscription) warnDue(now int64) (uint64, bool) {
+	last := s.lastWarn.Load()
+	if (last != 0 && now-last < int64(dropWarnInterval)) || !s.lastWarn.CompareAndSwap(last, now) {
+		s.unlogged.Add(1)
+		return 0, false
+	}
+	return s.unlogged.Swap(0), true
+}
@@ -0,0 +1,130 @@
+// event/policy_test.go
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// drain returns the values of every queued event.
+func drain(sub *Subscription) []int64 {
+	var got []int64
+	for {
+		select {
+		case evt := <-sub.C:
+			got = append(got, evt.Value)
+		default:
+			return got
+		}
+	}
+}
+
+func TestBackpressurePolicies(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		want        []int64
+		wantDropped uint64
+	}{
+		{"drop newest", []Option{WithBuffer(2)}, []int64{1, 2}, 2},
+		{"drop oldest", []Option{WithBuffer(2), WithPolicy(DropOldest)}, []int64{3, 4}, 2},
+		{"keep latest", []Option{WithBuffer(8), WithPolicy(KeepLatest)}, []int64{4}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBus()
+			sub := b.SubscribeContext(context.Background(), "t", tt.opts...)
+			defer sub.Unsubscribe()
+
+			var lost int
+			for i := int64(1); i <= 4; i++ {
+				lost += b.Publish("t", i, nil, "test")
+			}
+
+			got := drain(sub)
+			if len(got) != len(tt.want) {
+				t.Fatalf("queued %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("queued %v, want %v", got, tt.want)
+				}
+			}
+
+			st := sub.Stats()
+			if st.Dropped != tt.wantDropped || uint64(lost) != tt.wantDropped {
+				t.Fatalf("Dropped = %d, Publish reported %d, want %d", st.Dropped, lost, tt.wantDropped)
+			}
+			if st.Delivered != 4-tt.wantDropped {
+				t.Fatalf("Delivered = %d, want %d", st.Delivered, 4-tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestBlockWaitsForConsumer(t *testing.T) {
+	b := NewBus()
+	sub := b.SubscribeContext(context.Background(), "t", WithBuffer(1), WithBlockTimeout(time.Second))
+	defer sub.Unsubscribe()
+
+	b.Publish("t", 1, nil, "test")
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		<-sub.C
+	}()
+
+	begin := time.Now()
+	if lost := b.Publish("t", 2, nil, "test"); lost != 0 {
+		t.Fatalf("Publish lost %d events, want the consumer to make room", lost)
+	}
+	if waited := time.Since(begin); waited < 10*time.Millisecond {
+		t.Fatalf("Publish returned after %v without waiting", waited)
+	}
+	if evt := <-sub.C; evt.Value != 2 {
+		t.Fatalf("got %d, want 2", evt.Value)
+	}
+}
+
+func TestBlockTimesOut(t *testing.T) {
+	b := NewBus()
+	sub := b.SubscribeContext(context.Background(), "t", WithBuffer(1), WithBlockTimeout(20*time.Millisecond))
+	defer sub.Unsubscribe()
+	// A non-blocking subscriber on the same topic is served before the wait.
+	fast := b.SubscribeContext(context.Background(), "t")
+	defer fast.Unsubscribe()
+
+	b.Publish("t", 1, nil, "test")
+	begin := time.Now()
+	if lost := b.Publish("t", 2, nil, "test"); lost != 1 {
+		t.Fatalf("Publish lost %d events, want 1", lost)
+	}
+	if waited := time.Since(begin); waited < 20*time.Millisecond {
+		t.Fatalf("Publish gave up after %v, before the timeout", waited)
+	}
+	if got := drain(fast); len(got) != 2 {
+		t.Fatalf("fast subscriber got %v, want both events", got)
+	}
+	if st := sub.Stats(); st.Dropped != 1 || st.Delivered != 1 {
+		t.Fatalf("stats = %+v", st)
+	}
+}
+
+func TestDropWarningsAreRateLimited(t *testing.T) {
+	s := &Subscription{}
+	t0 := time.Now().UnixNano()
+	ms := int64(time.Millisecond)
+
+	if n, ok := s.warnDue(t0); !ok || n != 0 {
+		t.Fatalf("first drop: warnDue = %d, %v; want a warning", n, ok)
+	}
+	for i := int64(1); i <= 5; i++ {
+		if _, ok := s.warnDue(t0 + i*ms); ok {
+			t.Fatalf("drop %d within the interval was logged", i)
+		}
+	}
+	if n, ok := s.warnDue(t0 + int64(dropWarnInterval)); !ok || n != 5 {
+		t.Fatalf("after the interval: warnDue = %d, %v; want a warning counting 5", n, ok)
+	}
+}
@@ -0,0 +1,116 @@
+// event/priority.go
+package event
+
+import "context"
+
+// Priority orders delivery between the lanes of a subscription.
+type Priority uint8
+
+const (
+	// PriorityNormal is the default for all events.
+	PriorityNormal Priority = iota
+	// PriorityHigh events go to the high lane of subscribers that have one,
+	// so a burst of telemetry cannot crowd out e.g. a "stop motor" command.
+	PriorityHigh
+)
+
+// PublishPriority publishes an event with a priority on the default bus.
+func PublishPriority(p Priority, topic string, value int64, payload interface{}, source string) int {
+	return defaultBus.PublishPriority(p, topic, value, payload, source)
+}
+
+// PublishPriority publishes an event with the given priority.
+func (b *Bus) PublishPriority(p Priority, topic string, value int64, payload interface{}, source string) int {
+	return b.publish(Event{
+		Topic:    topic,
+		Value:    value,
+		Payload:  payload,
+		Source:   source,
+		Priority: p,
+	}, false)
+}
+
+// PriorityFor returns middleware that raises every event matching filter to
+// priority p, so whole topics can be prioritized without changing publishers.
+//
+// Usage (during boot):
+//
+//	event.Use(event.PriorityFor("app/command/#", event.PriorityHigh))
+func PriorityFor(filter string, p Priority) Middleware {
+	return func(evt *Event) error {
+		if evt.Priority < p && Match(filter, evt.Topic) {
+			evt.Priority = p
+		}
+		return nil
+	}
+}
+
+// WithHighLane gives the subscription a separate channel H of the given
+// capacity for high-priority events. Both lanes share the backpressure policy
+// but fill up independently.
+func WithHighLane(size int) Option {
+	return func(s *Subscription) {
+		if size > 0 {
+			s.hiSize = size
+		}
+	}
+}
+
+// lane returns the channel an event is queued on.
+func (s *Subscription) lane(evt Event) chan Event {
+	if s.hi != nil && evt.Priority >= PriorityHigh {
+		return s.hi
+	}
+	return s.ch
+}
+
+// Recv returns the next event, taking queued high-priority events ahead of
+// normal ones. It returns false once ctx is done, or once the subscription
+// has ended and both lanes are drained.
+//
+// Usage (inside Module.Start):
+//
+//	sub := event.SubscribeContext(ctx, "motor/#", event.WithHighLane(4))
+//	for {
+//		evt, ok := sub.Recv(ctx)
+//		if !ok {
+//			return
+//		}
+//		...
+//	}
+func (s *Subscription) Recv(ctx context.Context) (Event, bool) {
+	hi := s.hi
+	if hi != nil {
+		select {
+		case evt, ok := <-hi:
+			if ok {
+				return evt, true
+			}
+			hi = nil // Closed and drained; C may still hold events.
+		default:
+		}
+	}
+
+	for {
+		select {
+		case evt, ok := <-hi:
+			if ok {
+				return evt, true
+			}
+			hi = nil
+		case evt, ok := <-s.ch:
+			if ok {
+				return evt, true
+			}
+			// Both lanes are closed together; hand out what is left on H.
+			if hi != nil {
+				if evt, ok := <-hi; ok {
+					return evt, true
+				}
+			}
+			return Event{}, false
+		case <-ctx.Done():
+			return Event{}, false
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// event/priority_test.go
+package event
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRecvPrefersHighLane(t *testing.T) {
+	b := NewBus()
+	b.Use(PriorityFor("motor/stop", PriorityHigh))
+	sub := b.SubscribeContext(context.Background(), "#", WithBuffer(3), WithHighLane(2))
+	defer sub.Unsubscribe()
+
+	for i := 0; i < 5; i++ {
+		b.Publish("telemetry", int64(i), nil, "test")
+	}
+	b.Publish("motor/stop", 0, nil, "test")
+	b.PublishPriority(PriorityHigh, "alarm", 0, nil, "test")
+
+	want := []string{"motor/stop", "alarm", "telemetry", "telemetry", "telemetry"}
+	for i, topic := range want {
+		evt, ok := sub.Recv(context.Background())
+		if !ok || evt.Topic != topic {
+			t.Fatalf("event %d = %q (%v), want %q", i, evt.Topic, ok, topic)
+		}
+	}
+
+	st := sub.Stats()
+	if st.HighLane.Capacity != 2 || st.HighLane.HighWater != 2 || st.Dropped != 2 {
+		t.Fatalf("stats = %+v", st)
+	}
+}
+
+func TestRecvDrainsBothLanesAfterUnsubscribe(t *testing.T) {
+	b := NewBus()
+	sub := b.SubscribeContext(context.Background(), "#", WithHighLane(2))
+
+	b.Publish("normal", 1, nil, "test")
+	b.Publish("normal", 2, nil, "test")
+	b.PublishPriority(PriorityHigh, "high", 3, nil, "test")
+	sub.Unsubscribe()
+
+	var got []int64
+	for {
+		evt, ok := sub.Recv(context.Background())
+		if !ok {
+			break
+		}
+		got = append(got, evt.Value)
+	}
+	if len(got) != 3 || got[0] != 3 {
+		t.Fatalf("got %v, want [3 1 2]", got)
+	}
+}
+
+func TestRecvContextDone(t *testing.T) {
+	b := NewBus()
+	sub := b.SubscribeContext(context.Background(), "#", WithHighLane(1))
+	defer sub.Unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, ok := sub.Recv(ctx); ok {
+		t.Fatal("Recv returned an event on a cancelled context")
+	}
+}
+
+func TestKeepLatestHighLane(t *testing.T) {
+	b := NewBus()
+	sub := b.SubscribeContext(context.Background(), "#", WithPolicy(KeepLatest), WithHighLane(4))
+	defer sub.Unsubscribe()
+
+	for i := 0; i < 4; i++ {
+		b.PublishPriority(PriorityHigh, "state", int64(i), nil, "test")
+	}
+
+	if n := len(sub.H); n != 1 {
+		t.Fatalf("high lane holds %d events, want 1", n)
+	}
+	if evt := <-sub.H; evt.Value != 3 {
+		t.Fatalf("kept %d, want the latest (3)", evt.Value)
+	}
+}
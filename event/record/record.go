@@ -0,0 +1,169 @@
+// event/record/record.go
+package record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/magradze/gonnect/event"
+	"github.com/magradze/gonnect/pkg/cbor"
+	"github.com/magradze/gonnect/pkg/logger"
+)
+
+// Recordings are a stream of CBOR arrays, one per event, so a log can be
+// appended to and read back without an index. The package lives outside
+// event so firmware that never records does not link the CBOR codec.
+
+// ErrCorrupt is returned when a recording cannot be decoded.
+var ErrCorrupt = errors.New("record: corrupt recording")
+
+// Entry is one recorded event.
+// Payload holds the CBOR-encoded Event.Payload (nil if there was none
+// or it could not be encoded).
+type Entry struct {
+	_ struct{} `cbor:",toarray"`
+
+	Topic     string
+	Value     int64
+	Payload   []byte
+	Source    string
+	Timestamp int64
+	Retained  bool
+
+	Priority      event.Priority
+	ReplyTo       string
+	CorrelationID uint64
+}
+
+// Record captures every event matching filter ("#" for everything) into w
+// until ctx is done. It returns the number of events written.
+// The recorder is an ordinary subscriber: options set its buffer and policy,
+// and its losses show up in the bus Stats. With event.WithHighLane both
+// lanes are drained, high-priority events first.
+//
+// Usage (host test or debug build):
+//
+//	go record.Record(ctx, bus, "#", file, event.WithBuffer(64))
+func Record(ctx context.Context, bus *event.Bus, filter string, w io.Writer, opts ...event.Option) (int, error) {
+	sub := bus.SubscribeContext(ctx, filter, opts...)
+	defer sub.Unsubscribe()
+
+	enc := cbor.NewEncoder(w)
+	n := 0
+	for {
+		evt, ok := sub.Recv(context.Background())
+		if !ok {
+			break
+		}
+		entry := Entry{
+			Topic:     evt.Topic,
+			Value:     evt.Value,
+			Source:    evt.Source,
+			Timestamp: evt.Timestamp,
+			Retained:  evt.Retained,
+
+			Priority:      evt.Priority,
+			ReplyTo:       evt.ReplyTo,
+			CorrelationID: evt.CorrelationID,
+		}
+		if evt.Payload != nil {
+			raw, err := cbor.Marshal(evt.Payload)
+			if err != nil {
+				logger.Warn("Record: Payload of '%s' not encodable: %v", evt.Topic, err)
+			} else {
+				entry.Payload = raw
+			}
+		}
+
+		if err := enc.Encode(entry); err != nil {
+			return n, fmt.Errorf("record: write failed: %w", err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// Replayer feeds a recording back into a bus.
+type Replayer struct {
+	// Speed scales the recorded gaps between events: 1 keeps the original
+	// timing, 2 plays twice as fast. Zero replays as fast as possible.
+	Speed float64
+
+	// Decode turns a recorded payload back into the type consumers expect.
+	// If nil, events carry the cbor.RawMessage (or nil if none was recorded).
+	Decode func(topic string, raw cbor.RawMessage) (interface{}, error)
+}
+
+// Replay publishes every entry read from r on bus, in order, and returns the
+// number of events published. It stops early when ctx is done.
+// Retained entries are published as retained, and priorities and request
+// fields are restored, so events land in the same lanes as when recorded.
+func (p *Replayer) Replay(ctx context.Context, bus *event.Bus, r io.Reader) (int, error) {
+	dec := cbor.NewDecoder(r)
+	var last int64
+	n := 0
+
+	for {
+		var entry Entry
+		if err := dec.Decode(&entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, fmt.Errorf("%w: entry %d: %v", ErrCorrupt, n, err)
+		}
+
+		if p.Speed > 0 && n > 0 {
+			gap := time.Duration(float64(entry.Timestamp-last) / p.Speed)
+			if err := sleep(ctx, gap); err != nil {
+				return n, err
+			}
+		} else if err := ctx.Err(); err != nil {
+			return n, err
+		}
+		last = entry.Timestamp
+
+		var payload interface{}
+		if entry.Payload != nil {
+			payload = cbor.RawMessage(entry.Payload)
+			if p.Decode != nil {
+				v, err := p.Decode(entry.Topic, cbor.RawMessage(entry.Payload))
+				if err != nil {
+					return n, fmt.Errorf("record: payload of '%s': %w", entry.Topic, err)
+				}
+				payload = v
+			}
+		}
+
+		bus.PublishEvent(event.Event{
+			Topic:         entry.Topic,
+			Value:         entry.Value,
+			Payload:       payload,
+			Source:        entry.Source,
+			Retained:      entry.Retained,
+			Priority:      entry.Priority,
+			ReplyTo:       entry.ReplyTo,
+			CorrelationID: entry.CorrelationID,
+		})
+		n++
+	}
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,68 @@
+// event/record/record_test.go
+package record
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/magradze/gonnect/event"
+)
+
+func TestRecordReplayRoundTrip(t *testing.T) {
+	src := event.NewBus()
+	var log bytes.Buffer
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan int)
+	go func() {
+		// The high lane must be recorded too, not left behind on H.
+		n, err := Record(ctx, src, "#", &log, event.WithBuffer(16), event.WithHighLane(4))
+		if err != nil {
+			t.Error(err)
+		}
+		done <- n
+	}()
+	// Publish only once the recorder is subscribed.
+	waitSubscribers(t, src, 1)
+
+	src.Publish("telemetry", 1, nil, "sensor")
+	src.PublishPriority(event.PriorityHigh, "motor/stop", 2, "now", "console")
+	src.PublishRetained("light/state", 1, nil, "led")
+	// Deliveries are synchronous and the recorder drains its buffer after cancel.
+	cancel()
+	if n := <-done; n != 3 {
+		t.Fatalf("recorded %d events, want 3", n)
+	}
+
+	dst := event.NewBus()
+	sub := dst.SubscribeContext(context.Background(), "#", event.WithHighLane(4))
+	defer sub.Unsubscribe()
+
+	p := Replayer{}
+	if n, err := p.Replay(context.Background(), dst, bytes.NewReader(log.Bytes())); n != 3 || err != nil {
+		t.Fatalf("Replay = %d, %v", n, err)
+	}
+
+	if len(sub.H) != 1 {
+		t.Fatalf("high lane holds %d events, want 1", len(sub.H))
+	}
+	stop := <-sub.H
+	if stop.Topic != "motor/stop" || stop.Priority != event.PriorityHigh || stop.Source != "console" {
+		t.Fatalf("high-priority event = %+v", stop)
+	}
+	if _, ok := dst.Retained("light/state"); !ok {
+		t.Fatal("retained state was not restored")
+	}
+}
+
+func waitSubscribers(t *testing.T, b *event.Bus, n int) {
+	t.Helper()
+	for i := 0; len(b.Stats().Subscribers) < n; i++ {
+		if i > 1000 {
+			t.Fatal("recorder did not subscribe")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
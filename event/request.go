@@ -0,0 +1,136 @@
+// event/request.go
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// ReplyPrefix is the topic namespace used for request replies.
+const ReplyPrefix = "_reply/"
+
+var (
+	// ErrNoResponder is returned when nobody subscribes to the request topic.
+	// Catch-all subscriptions such as "#" (loggers, recorders) do not count.
+	ErrNoResponder = errors.New("event: no responder")
+	// ErrNotRequest is returned when replying to an event without a reply topic.
+	ErrNotRequest = errors.New("event: not a request")
+)
+
+// requestSeq generates correlation IDs, shared by all buses.
+var requestSeq atomic.Uint64
+
+// Request sends a request on the default bus and waits for the reply.
+func Request(ctx context.Context, topic string, value int64, payload interface{}, source string) (Event, error) {
+	return defaultBus.Request(ctx, topic, value, payload, source)
+}
+
+// Reply answers a request received from the default bus.
+func Reply(req Event, value int64, payload interface{}, source string) error {
+	return defaultBus.Reply(req, value, payload, source)
+}
+
+// Request publishes an event carrying a fresh correlation ID and a private
+// reply topic, then waits for the first reply or until ctx is done.
+// Use context.WithTimeout to bound the wait.
+//
+// If no subscription can answer, it fails fast with ErrNoResponder. Filters
+// made only of wildcards and ending in "#" ("#", "+/#") are taken for taps
+// and ignored; any other matching filter, even a broad one like "sensor/#",
+// counts as a responder, and a request it does not answer waits for ctx.
+//
+// Usage (console module asking the sensor module):
+//
+//	ctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+//	defer cancel()
+//	reply, err := event.Request(ctx, "sensor/temperature/get", 0, nil, "console")
+//
+// The responder side:
+//
+//	for req := range sub.C { event.Reply(req, reading, nil, "sensor") }
+func (b *Bus) Request(ctx context.Context, topic string, value int64, payload interface{}, source string) (Event, error) {
+	id := requestSeq.Add(1)
+	replyTo := ReplyPrefix + strconv.FormatUint(id, 10)
+
+	// Subscribe before publishing so a fast responder cannot be missed.
+	sub := b.SubscribeContext(ctx, replyTo, WithBuffer(1))
+	defer sub.Unsubscribe()
+
+	if !b.hasResponder(topic) {
+		return Event{}, fmt.Errorf("%w: %s", ErrNoResponder, topic)
+	}
+
+	b.publish(Event{
+		Topic:         topic,
+		Value:         value,
+		Payload:       payload,
+		Source:        source,
+		ReplyTo:       replyTo,
+		CorrelationID: id,
+	}, false)
+
+	select {
+	case evt, ok := <-sub.C:
+		if !ok {
+			// Closed because ctx ended before a reply arrived.
+			return Event{}, ctx.Err()
+		}
+		return evt, nil
+	case <-ctx.Done():
+		return Event{}, ctx.Err()
+	}
+}
+
+// Reply publishes the answer to a request on its reply topic.
+// A reply that arrives after the requester gave up is silently discarded.
+func (b *Bus) Reply(req Event, value int64, payload interface{}, source string) error {
+	if req.ReplyTo == "" {
+		return fmt.Errorf("%w: %s", ErrNotRequest, req.Topic)
+	}
+
+	b.publish(Event{
+		Topic:         req.ReplyTo,
+		Value:         value,
+		Payload:       payload,
+		Source:        source,
+		CorrelationID: req.CorrelationID,
+	}, false)
+	return nil
+}
+
+// hasResponder reports whether a subscription other than a catch-all tap matches the topic.
+func (b *Bus) hasResponder(topic string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	matched := b.root.match(topic, b.scratch[:0])
+	b.scratch = matched
+	found := false
+	for _, s := range matched {
+		if !catchAll(s.filter) {
+			found = true
+			break
+		}
+	}
+	clear(matched)
+	return found
+}
+
+// catchAll reports whether a filter has only wildcard levels and ends in "#".
+func catchAll(filter string) bool {
+	rest, more := filter, true
+	for more {
+		var level string
+		level, rest, more = nextLevel(rest)
+		if level == MultiLevel {
+			return !more
+		}
+		if level != SingleLevel {
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+// event/request_test.go
+package event
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// respond answers every request on topic with the request value doubled.
+func respond(b *Bus, topic string) *Subscription {
+	sub := b.SubscribeContext(context.Background(), topic)
+	go func() {
+		for req := range sub.C {
+			b.Reply(req, req.Value*2, nil, "responder")
+		}
+	}()
+	return sub
+}
+
+func TestRequestReply(t *testing.T) {
+	b := NewBus()
+	defer respond(b, "math/double").Unsubscribe()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for i := int64(1); i <= 3; i++ {
+		reply, err := b.Request(ctx, "math/double", i, nil, "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reply.Value != 2*i || reply.Source != "responder" || reply.CorrelationID == 0 {
+			t.Fatalf("reply = %+v", reply)
+		}
+		if !strings.HasPrefix(reply.Topic, ReplyPrefix) {
+			t.Fatalf("reply topic %q outside %q", reply.Topic, ReplyPrefix)
+		}
+	}
+}
+
+func TestRequestNoResponder(t *testing.T) {
+	b := NewBus()
+	// Taps see every request but cannot answer it.
+	defer b.SubscribeContext(context.Background(), "#").Unsubscribe()
+	defer b.SubscribeContext(context.Background(), "+/#").Unsubscribe()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	begin := time.Now()
+	_, err := b.Request(ctx, "math/double", 1, nil, "test")
+	if !errors.Is(err, ErrNoResponder) {
+		t.Fatalf("Request = %v, want ErrNoResponder", err)
+	}
+	if waited := time.Since(begin); waited > 100*time.Millisecond {
+		t.Fatalf("Request waited %v before failing", waited)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	b := NewBus()
+	// A matching subscriber that never answers.
+	silent := b.SubscribeContext(context.Background(), "math/+")
+	defer silent.Unsubscribe()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := b.Request(ctx, "math/double", 1, nil, "test"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Request = %v, want DeadlineExceeded", err)
+	}
+
+	// The late reply has nowhere to go and is discarded.
+	req := <-silent.C
+	if err := b.Reply(req, 2, nil, "late"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(b.Stats().Subscribers); n != 1 {
+		t.Fatalf("%d subscriptions left, want only the silent one", n)
+	}
+}
+
+func TestReplyToPlainEvent(t *testing.T) {
+	if err := NewBus().Reply(Event{Topic: "a"}, 0, nil, "test"); !errors.Is(err, ErrNotRequest) {
+		t.Fatalf("Reply = %v, want ErrNotRequest", err)
+	}
+}
+
+func TestCatchAll(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"#", true},
+		{"+/#", true},
+		{"+/+/#", true},
+		{"a/#", false},
+		{"+", false},
+		{"+/+", false},
+		{"a", false},
+	}
+	for _, tt := range tests {
+		if got := catchAll(tt.filter); got != tt.want {
+			t.Errorf("catchAll(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// event/retained.go
+package event
+
+// PublishRetained publishes an event on the default bus and keeps it as the topic's current state.
+func PublishRetained(topic string, value int64, payload interface{}, source string) int {
+	return defaultBus.PublishRetained(topic, value, payload, source)
+}
+
+// Retained returns the current retained event of a topic on the default bus.
+func Retained(topic string) (Event, bool) {
+	return defaultBus.Retained(topic)
+}
+
+// PublishRetained publishes an event and stores it as the last value of the topic.
+// Subscribers registered later receive it immediately (Event.Retained is set),
+// so a restarted module learns the current state, e.g. "light is on".
+func (b *Bus) PublishRetained(topic string, value int64, payload interface{}, source string) int {
+	return b.publish(Event{
+		Topic:   topic,
+		Value:   value,
+		Payload: payload,
+		Source:  source,
+	}, true)
+}
+
+// retain stores evt as the last value of its topic. The caller must hold b.mu.
+func (b *Bus) retain(evt Event) {
+	if b.retained == nil {
+		b.retained = make(map[string]Event)
+	}
+	b.retained[evt.Topic] = evt
+}
+
+// Retained returns the last retained event of a topic, acting as a synchronous state read.
+func (b *Bus) Retained(topic string) (Event, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	evt, ok := b.retained[topic]
+	return evt, ok
+}
+
+// ClearRetained forgets the retained event of a topic.
+func (b *Bus) ClearRetained(topic string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.retained, topic)
+}
+
+// replayRetained queues every retained event matching the subscription's filter.
+// It runs under b.mu right after registration, so no live event can overtake it.
+// Block subscribers are not waited on here; a fresh buffer normally has room.
+func (b *Bus) replayRetained(s *Subscription) {
+	for topic, evt := range b.retained {
+		if !Match(s.filter, topic) || !s.accepts(evt) {
+			continue
+		}
+		s.sendMu.Lock()
+		s.offer(evt)
+		s.sendMu.Unlock()
+	}
+}
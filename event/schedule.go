@@ -0,0 +1,208 @@
+// event/schedule.go
+package event
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Timer is a scheduled publication created by PublishAfter or PublishEvery.
+// All timers of a bus share one heap and one goroutine, which only runs
+// while timers are pending, instead of a goroutine and ticker per module.
+type Timer struct {
+	bus   *Bus
+	evt   Event
+	at    time.Time
+	every time.Duration
+	index int // Position in the heap, -1 when not pending. Guarded by bus.sched.mu.
+}
+
+// scheduler is the bus timer heap. The zero value is ready to use.
+type scheduler struct {
+	mu      sync.Mutex
+	timers  timerHeap
+	running bool
+	wake    chan struct{}
+}
+
+// PublishAfter publishes an event on the default bus once, after d.
+func PublishAfter(d time.Duration, topic string, value int64, payload interface{}, source string) *Timer {
+	return defaultBus.PublishAfter(d, topic, value, payload, source)
+}
+
+// PublishEvery publishes an event on the default bus every interval.
+func PublishEvery(interval time.Duration, topic string, value int64, payload interface{}, source string) *Timer {
+	return defaultBus.PublishEvery(interval, topic, value, payload, source)
+}
+
+// PublishAfter publishes an event once, after d. Stop the timer to cancel it.
+func (b *Bus) PublishAfter(d time.Duration, topic string, value int64, payload interface{}, source string) *Timer {
+	t := b.newTimer(topic, value, payload, source)
+	b.sched.add(t, time.Now().Add(d))
+	return t
+}
+
+// PublishEvery publishes an event every interval until the timer is stopped.
+// Ticks missed because the publisher was delayed are skipped, not bunched up.
+//
+// Usage (inside Module.Start):
+//
+//	t := event.PublishEvery(50*time.Millisecond, "smart_led/frame", 0, nil, ModuleName)
+//	defer t.Stop()
+func (b *Bus) PublishEvery(interval time.Duration, topic string, value int64, payload interface{}, source string) *Timer {
+	if interval <= 0 {
+		panic("event: non-positive interval for PublishEvery")
+	}
+	t := b.newTimer(topic, value, payload, source)
+	t.every = interval
+	b.sched.add(t, time.Now().Add(interval))
+	return t
+}
+
+func (b *Bus) newTimer(topic string, value int64, payload interface{}, source string) *Timer {
+	return &Timer{
+		bus:   b,
+		evt:   Event{Topic: topic, Value: value, Payload: payload, Source: source},
+		index: -1,
+	}
+}
+
+// Stop cancels the timer. It reports whether the timer was pending.
+func (t *Timer) Stop() bool {
+	s := &t.bus.sched
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.index < 0 {
+		return false
+	}
+	heap.Remove(&s.timers, t.index)
+	s.notify()
+	return true
+}
+
+// Reset re-arms the timer to fire after d, e.g. to restart a debounce or
+// inactivity timeout. It reports whether the timer was pending.
+// A periodic timer fires after d and then keeps its interval.
+func (t *Timer) Reset(d time.Duration) bool {
+	s := &t.bus.sched
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Remove and re-push in one critical section, so concurrent calls
+	// can never queue the same timer twice.
+	pending := t.index >= 0
+	if pending {
+		heap.Remove(&s.timers, t.index)
+	}
+	s.push(t, time.Now().Add(d))
+	return pending
+}
+
+// add queues a timer.
+func (s *scheduler) add(t *Timer, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.push(t, at)
+}
+
+// push queues a timer and starts the scheduler goroutine if needed.
+// The caller must hold s.mu.
+func (s *scheduler) push(t *Timer, at time.Time) {
+	t.at = at
+	heap.Push(&s.timers, t)
+
+	if s.wake == nil {
+		s.wake = make(chan struct{}, 1)
+	}
+	if !s.running {
+		s.running = true
+		go s.run()
+		return
+	}
+	if t.index == 0 {
+		s.notify()
+	}
+}
+
+// notify wakes the scheduler to re-check the earliest deadline.
+// The caller must hold s.mu.
+func (s *scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run fires due timers in deadline order. It exits when no timers are left.
+func (s *scheduler) run() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		if len(s.timers) == 0 {
+			s.running = false
+			s.mu.Unlock()
+			return
+		}
+
+		now := time.Now()
+		t := s.timers[0]
+		if wait := t.at.Sub(now); wait > 0 {
+			s.mu.Unlock()
+			timer.Reset(wait)
+			select {
+			case <-timer.C:
+			case <-s.wake:
+				timer.Stop()
+			}
+			continue
+		}
+
+		if t.every > 0 {
+			t.at = t.at.Add(t.every)
+			if !t.at.After(now) {
+				t.at = now.Add(t.every)
+			}
+			heap.Fix(&s.timers, 0)
+		} else {
+			heap.Pop(&s.timers)
+		}
+		evt := t.evt
+		s.mu.Unlock()
+
+		// Publishing outside the lock lets subscribers stop or reset timers.
+		t.bus.publish(evt, false)
+	}
+}
+
+// timerHeap orders timers by deadline (container/heap).
+type timerHeap []*Timer
+
+func (h timerHeap) Len() int           { return len(h) }
+func (h timerHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timerHeap) Push(x any) {
+	t := x.(*Timer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *timerHeap) Pop() any {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	t.index = -1
+	*h = old[:n-1]
+	return t
+}
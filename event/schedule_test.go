@@ -0,0 +1,83 @@
+// event/schedule_test.go
+package event
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// collect returns the events queued on sub after d.
+func collect(sub *Subscription, d time.Duration) []Event {
+	time.Sleep(d)
+	var out []Event
+	for len(sub.C) > 0 {
+		out = append(out, <-sub.C)
+	}
+	return out
+}
+
+func TestPublishAfterAndStop(t *testing.T) {
+	b := NewBus()
+	sub := b.SubscribeContext(context.Background(), "#")
+	defer sub.Unsubscribe()
+
+	b.PublishAfter(10*time.Millisecond, "once", 1, nil, "test")
+	cancelled := b.PublishAfter(10*time.Millisecond, "cancelled", 2, nil, "test")
+	if !cancelled.Stop() || cancelled.Stop() {
+		t.Fatal("Stop should report pending exactly once")
+	}
+
+	got := collect(sub, 50*time.Millisecond)
+	if len(got) != 1 || got[0].Topic != "once" {
+		t.Fatalf("got %v, want a single 'once' event", got)
+	}
+}
+
+func TestPublishEvery(t *testing.T) {
+	b := NewBus()
+	sub := b.SubscribeContext(context.Background(), "tick", WithBuffer(100))
+	defer sub.Unsubscribe()
+
+	tm := b.PublishEvery(5*time.Millisecond, "tick", 0, nil, "test")
+	time.Sleep(32 * time.Millisecond)
+	tm.Stop()
+
+	n := len(collect(sub, 20*time.Millisecond))
+	if n < 3 || n > 7 {
+		t.Fatalf("got %d ticks in ~30ms at 5ms", n)
+	}
+}
+
+func TestConcurrentResetFiresOnce(t *testing.T) {
+	b := NewBus()
+	sub := b.SubscribeContext(context.Background(), "debounce", WithBuffer(100))
+	defer sub.Unsubscribe()
+
+	tm := b.PublishAfter(time.Hour, "debounce", 0, nil, "test")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				tm.Reset(5 * time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+
+	b.sched.mu.Lock()
+	queued := len(b.sched.timers)
+	b.sched.mu.Unlock()
+	if queued != 1 {
+		t.Fatalf("timer queued %d times", queued)
+	}
+	if n := len(collect(sub, 40*time.Millisecond)); n != 1 {
+		t.Fatalf("one-shot timer fired %d times", n)
+	}
+	if tm.Stop() {
+		t.Fatal("timer still pending after firing")
+	}
+}
@@ -0,0 +1,154 @@
+// event/stats.go
+package event
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// MaxTrackedTopics caps the number of topics with their own counters, so
+// per-device topics (e.g. "sensors/<id>/temp") cannot grow the table without bound.
+const MaxTrackedTopics = 64
+
+// TopicStats holds the counters of a concrete topic.
+// Only topics that reached at least one subscriber are tracked; events
+// published to nobody cost nothing and are not counted.
+// Delivered counts deliveries that lost nothing; Dropped counts deliveries
+// where a subscriber lost an event (the new one, or an older one it evicted
+// under DropOldest). KeepLatest replacements count as delivered.
+type TopicStats struct {
+	Topic       string
+	Published   uint64
+	Delivered   uint64
+	Dropped     uint64
+	LastPublish int64 // UnixNano, like Event.Timestamp
+}
+
+// SubscriberStats holds the counters of one subscription.
+// Capacity, Queued and HighWater describe the normal lane (C); HighLane
+// describes the high-priority lane (H) and is zero without one.
+// HighWater is the largest channel occupancy seen right after a delivery;
+// a value close to Capacity means the consumer is falling behind.
+// Delivered and Dropped cover both lanes.
+type SubscriberStats struct {
+	Filter    string
+	Policy    Policy
+	Capacity  int
+	Queued    int
+	HighWater int
+	HighLane  LaneStats
+	Delivered uint64
+	Dropped   uint64
+}
+
+// LaneStats holds the occupancy of a subscription lane.
+type LaneStats struct {
+	Capacity  int
+	Queued    int
+	HighWater int
+}
+
+// BusStats is a snapshot of the bus counters, sorted by topic and filter.
+// Untracked counts publishes to topics beyond MaxTrackedTopics.
+type BusStats struct {
+	Topics      []TopicStats
+	Subscribers []SubscriberStats
+	Untracked   uint64
+}
+
+// topicCounters are updated by publish without holding the bus lock.
+type topicCounters struct {
+	published atomic.Uint64
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+	last      atomic.Int64
+}
+
+// Stats returns a snapshot of the default bus counters.
+func Stats() BusStats {
+	return defaultBus.Stats()
+}
+
+// counters returns the counters of a topic, creating them on first use.
+// Reply topics are unique per request and are not tracked, nor are new
+// topics once MaxTrackedTopics is reached.
+// The caller must hold b.mu.
+func (b *Bus) counters(topic string) *topicCounters {
+	if strings.HasPrefix(topic, ReplyPrefix) {
+		return nil
+	}
+	tc := b.topics[topic]
+	if tc == nil {
+		if len(b.topics) >= MaxTrackedTopics {
+			b.untracked++
+			return nil
+		}
+		if b.topics == nil {
+			b.topics = make(map[string]*topicCounters)
+		}
+		tc = &topicCounters{}
+		b.topics[topic] = tc
+	}
+	return tc
+}
+
+// Stats returns a snapshot of the per-topic and per-subscriber counters.
+func (b *Bus) Stats() BusStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st := BusStats{Untracked: b.untracked}
+	for topic, tc := range b.topics {
+		st.Topics = append(st.Topics, TopicStats{
+			Topic:       topic,
+			Published:   tc.published.Load(),
+			Delivered:   tc.delivered.Load(),
+			Dropped:     tc.dropped.Load(),
+			LastPublish: tc.last.Load(),
+		})
+	}
+	b.root.walk(func(s *Subscription) {
+		st.Subscribers = append(st.Subscribers, s.Stats())
+	})
+
+	sort.Slice(st.Topics, func(i, j int) bool { return st.Topics[i].Topic < st.Topics[j].Topic })
+	sort.SliceStable(st.Subscribers, func(i, j int) bool { return st.Subscribers[i].Filter < st.Subscribers[j].Filter })
+	return st
+}
+
+// Stats returns the counters of this subscription.
+func (s *Subscription) Stats() SubscriberStats {
+	return SubscriberStats{
+		Filter:    s.filter,
+		Policy:    s.policy,
+		Capacity:  s.size,
+		Queued:    s.queued(),
+		HighWater: int(s.highWater.Load()),
+		HighLane: LaneStats{
+			Capacity:  s.hiSize,
+			Queued:    len(s.hi),
+			HighWater: int(s.hiPeak.Load()),
+		},
+		Delivered: s.delivered.Load(),
+		Dropped:   s.dropped.Load(),
+	}
+}
+
+// record updates the subscription counters after a delivery.
+// The caller must hold s.sendMu.
+func (s *Subscription) record(p Priority, ok bool) {
+	if ok {
+		s.delivered.Add(1)
+	} else {
+		s.dropped.Add(1)
+	}
+
+	n, peak := s.queued(), &s.highWater
+	if s.hi != nil && p >= PriorityHigh {
+		n, peak = len(s.hi), &s.hiPeak
+	}
+	if int32(n) > peak.Load() {
+		peak.Store(int32(n))
+	}
+}
@@ -0,0 +1,45 @@
+// event/stats_test.go
+package event
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestStatsTrackOnlyObservedTopics(t *testing.T) {
+	b := NewBus()
+	sub := b.SubscribeContext(context.Background(), "sensors/+/temp", WithBuffer(1))
+	defer sub.Unsubscribe()
+
+	b.Publish("nobody/listens", 1, nil, "test")
+	b.Publish("sensors/a/temp", 1, nil, "test")
+	b.Publish("sensors/a/temp", 2, nil, "test") // buffer full: dropped
+
+	st := b.Stats()
+	if len(st.Topics) != 1 {
+		t.Fatalf("tracked topics = %+v, want only sensors/a/temp", st.Topics)
+	}
+	ts := st.Topics[0]
+	if ts.Topic != "sensors/a/temp" || ts.Published != 2 || ts.Delivered != 1 || ts.Dropped != 1 {
+		t.Fatalf("topic stats = %+v", ts)
+	}
+	if ss := sub.Stats(); ss.Delivered != 1 || ss.Dropped != 1 || ss.HighWater != 1 {
+		t.Fatalf("subscriber stats = %+v", ss)
+	}
+}
+
+func TestStatsTopicCap(t *testing.T) {
+	b := NewBus()
+	sub := b.SubscribeContext(context.Background(), "sensors/#", WithBuffer(1))
+	defer sub.Unsubscribe()
+
+	for i := 0; i < MaxTrackedTopics+10; i++ {
+		b.Publish("sensors/"+strconv.Itoa(i), 0, nil, "test")
+	}
+
+	st := b.Stats()
+	if len(st.Topics) != MaxTrackedTopics || st.Untracked != 10 {
+		t.Fatalf("tracked %d topics, %d untracked", len(st.Topics), st.Untracked)
+	}
+}
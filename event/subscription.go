@@ -0,0 +1,188 @@
+// event/subscription.go
+package event
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/magradze/gonnect/pkg/logger"
+)
+
+// Subscription is a handle to a registered listener.
+// Receive events from C; the channel is closed once the subscription ends,
+// so `for evt := range sub.C` terminates cleanly.
+type Subscription struct {
+	// C delivers the matching events.
+	C <-chan Event
+	// H delivers high-priority events when the subscription has a high lane
+	// (WithHighLane); it is nil otherwise and such events arrive on C.
+	H <-chan Event
+
+	ch     chan Event
+	hi     chan Event
+	bus    *Bus
+	filter string
+	// sink replaces ch and hi for typed subscriptions (see Topic.Subscribe).
+	sink sink
+
+	size    int
+	hiSize  int
+	policy  Policy
+	timeout time.Duration
+
+	// removed and stop are guarded by bus.mu; stop detaches the context callback.
+	removed bool
+	stop    func() bool
+
+	// sendMu serializes sends with close; closed is guarded by it.
+	sendMu sync.Mutex
+	closed bool
+
+	// Counters are written under sendMu and read atomically by Stats.
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+	highWater atomic.Int32
+	hiPeak    atomic.Int32
+
+	// lastWarn (UnixNano) and unlogged rate-limit drop warnings.
+	lastWarn atomic.Int64
+	unlogged atomic.Uint64
+}
+
+// SubscribeContext registers a listener on the default bus that is removed
+// automatically when ctx is done.
+func SubscribeContext(ctx context.Context, topic string, opts ...Option) *Subscription {
+	return defaultBus.SubscribeContext(ctx, topic, opts...)
+}
+
+// SubscribeContext registers a listener for a topic filter and returns its handle.
+// The subscription ends when ctx is done or Unsubscribe is called, whichever comes first.
+// Pass context.Background() for a subscription that lives until Unsubscribe.
+// Options set the buffer size and the backpressure policy.
+//
+// Usage (inside Module.Start):
+//
+//	sub := event.SubscribeContext(ctx, "app/command/#", event.WithBlockTimeout(50*time.Millisecond))
+//	for evt := range sub.C { ... }
+func (b *Bus) SubscribeContext(ctx context.Context, topic string, opts ...Option) *Subscription {
+	return b.subscribe(ctx, topic, nil, opts)
+}
+
+// sink is the queue of a subscription that does not receive Events, such as
+// a typed Topic subscription. All methods except depth run under sendMu.
+type sink interface {
+	// accepts reports whether the sink can take the event's payload.
+	accepts(evt Event) bool
+	// put queues an accepted event like deliver; a zero timeout never waits.
+	put(evt Event, p Policy, timeout time.Duration) bool
+	// depth returns the number of queued values.
+	depth() int
+	close()
+}
+
+// subscribe registers a subscription. If newSink is set, the subscription
+// queues into the sink it returns for the configured buffer size instead of C;
+// such subscriptions have no high lane.
+func (b *Bus) subscribe(ctx context.Context, topic string, newSink func(size int) sink, opts []Option) *Subscription {
+	s := &Subscription{bus: b, filter: topic, size: DefaultBufferSize}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if newSink != nil {
+		s.hiSize = 0
+	}
+	if s.policy == KeepLatest {
+		s.size = 1
+		if s.hiSize > 0 {
+			s.hiSize = 1
+		}
+	}
+	if s.policy == Block && s.timeout <= 0 {
+		s.timeout = DefaultBlockTimeout
+	}
+	if newSink != nil {
+		s.sink = newSink(s.size)
+	} else {
+		s.ch = make(chan Event, s.size)
+		s.C = s.ch
+	}
+	if s.hiSize > 0 {
+		s.hi = make(chan Event, s.hiSize)
+		s.H = s.hi
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !ValidFilter(topic) {
+		logger.Warn("EventBus: Malformed filter '%s', wildcards will match literally", topic)
+	}
+
+	n := b.root.insert(topic)
+	n.subs = append(n.subs, s)
+	logger.Debug("EventBus: New subscriber for '%s'", topic)
+
+	if len(b.retained) > 0 {
+		b.replayRetained(s)
+	}
+
+	// AfterFunc does not spawn a goroutine for standard cancellable contexts.
+	if ctx.Done() != nil {
+		s.stop = context.AfterFunc(ctx, s.Unsubscribe)
+	}
+
+	return s
+}
+
+// accepts reports whether the subscription takes the event; a typed
+// subscription skips events whose payload has another type.
+func (s *Subscription) accepts(evt Event) bool {
+	return s.sink == nil || s.sink.accepts(evt)
+}
+
+// queued returns the number of events waiting in the normal lane.
+func (s *Subscription) queued() int {
+	if s.sink != nil {
+		return s.sink.depth()
+	}
+	return len(s.ch)
+}
+
+// Topic returns the filter this subscription was registered with.
+func (s *Subscription) Topic() string {
+	return s.filter
+}
+
+// Unsubscribe removes the listener from the bus and closes C (and H).
+// It is safe to call more than once and from any goroutine.
+func (s *Subscription) Unsubscribe() {
+	b := s.bus
+	b.mu.Lock()
+	if s.removed {
+		b.mu.Unlock()
+		return
+	}
+	s.removed = true
+	if s.stop != nil {
+		s.stop()
+	}
+	b.root.remove(s.filter, s)
+	b.mu.Unlock()
+
+	// A Block delivery may still be in flight outside b.mu; sendMu orders the close after it.
+	s.sendMu.Lock()
+	s.closed = true
+	if s.sink != nil {
+		s.sink.close()
+	} else {
+		close(s.ch)
+	}
+	if s.hi != nil {
+		close(s.hi)
+	}
+	s.sendMu.Unlock()
+
+	logger.Debug("EventBus: Subscriber for '%s' removed", s.filter)
+}
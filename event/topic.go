@@ -0,0 +1,168 @@
+// event/topic.go
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// Topic is a typed, named event stream on a Bus.
+// Consumers receive T through `chan T`, so they need neither magic numbers
+// nor type assertions.
+//
+// A Topic is a typed view of the bus topic with the same name: values travel
+// as Event.Payload, so typed subscribers see everything published on that
+// topic (bus.Publish, PublishAfter/PublishEvery, ISR sources, replay), pass
+// through the middleware chain, and get the usual options, retained values
+// and statistics. Two Topic values with the same name are interchangeable.
+// Events whose payload is not a T are skipped by typed subscribers.
+//
+// Typed subscribers queue T directly, without a forwarding goroutine. While no
+// middleware is installed and only typed subscribers listen on the topic,
+// Publish hands v to them without boxing it into an Event.
+//
+// Usage:
+//
+//	var Brightness = event.NewTopic[uint8]("light/brightness")
+//
+//	Brightness.Publish(128, ModuleName)
+//	sub := Brightness.Subscribe(ctx)
+//	for level := range sub.C { ... }
+type Topic[T any] struct {
+	name string
+	bus  *Bus
+}
+
+// TypedSubscription is a handle to a typed listener.
+// C is closed once the subscription ends.
+type TypedSubscription[T any] struct {
+	// C delivers the values.
+	C <-chan T
+
+	sub *Subscription
+}
+
+// typedSink queues the payloads of a typed subscription.
+type typedSink[T any] struct {
+	ch chan T
+}
+
+func (k *typedSink[T]) accepts(evt Event) bool {
+	_, ok := evt.Payload.(T)
+	return ok
+}
+
+func (k *typedSink[T]) put(evt Event, p Policy, timeout time.Duration) bool {
+	return put(k.ch, evt.Payload.(T), p, timeout)
+}
+
+func (k *typedSink[T]) depth() int { return len(k.ch) }
+func (k *typedSink[T]) close()     { close(k.ch) }
+
+// typedValue is what Publish fans out on the direct path.
+type typedValue[T any] struct {
+	v      T
+	topic  string
+	source string
+}
+
+// NewTopic declares a typed topic on the default bus.
+// Declare topics as package-level variables and share them between modules.
+func NewTopic[T any](name string) *Topic[T] {
+	return NewTopicOn[T](defaultBus, name)
+}
+
+// NewTopicOn declares a typed topic on a specific bus.
+func NewTopicOn[T any](b *Bus, name string) *Topic[T] {
+	return &Topic[T]{name: name, bus: b}
+}
+
+// Name returns the topic string.
+func (t *Topic[T]) Name() string {
+	return t.name
+}
+
+// Subscribe registers a typed listener. It ends when ctx is done or Unsubscribe
+// is called. Options work as for Event subscribers and apply to C itself
+// (buffer size, backpressure policy); WithHighLane is ignored.
+func (t *Topic[T]) Subscribe(ctx context.Context, opts ...Option) *TypedSubscription[T] {
+	var ch chan T
+	sub := t.bus.subscribe(ctx, t.name, func(size int) sink {
+		ch = make(chan T, size)
+		return &typedSink[T]{ch: ch}
+	}, opts)
+	return &TypedSubscription[T]{C: ch, sub: sub}
+}
+
+// Publish publishes v on the topic. It returns the number of subscribers
+// (typed or not) that lost an event.
+func (t *Topic[T]) Publish(v T, source string) int {
+	if dropped, ok := t.publishDirect(v, source); ok {
+		return dropped
+	}
+	return t.bus.publish(Event{Topic: t.name, Payload: v, Source: source}, false)
+}
+
+// publishDirect queues v into the typed subscriptions without building an
+// Event. It reports false, leaving the work to publish, if middleware is
+// installed or another kind of subscriber listens on the topic.
+func (t *Topic[T]) publishDirect(v T, source string) (int, bool) {
+	b := t.bus
+	if chain := b.chain.Load(); chain != nil && len(*chain) > 0 {
+		return 0, false
+	}
+
+	b.mu.Lock()
+	subscribers := b.root.match(t.name, b.scratch[:0])
+	b.scratch = subscribers
+	for _, s := range subscribers {
+		if _, ok := s.sink.(*typedSink[T]); !ok {
+			clear(subscribers)
+			b.mu.Unlock()
+			return 0, false
+		}
+	}
+
+	tv := typedValue[T]{v: v, topic: t.name, source: source}
+	return fanOut(b, t.name, time.Now().UnixNano(), subscribers, tv, sendValue[T]), true
+}
+
+// sendValue is the typed counterpart of Subscription.send.
+func sendValue[T any](s *Subscription, tv typedValue[T]) bool {
+	s.sendMu.Lock()
+	ok := !s.closed && put(s.sink.(*typedSink[T]).ch, tv.v, s.policy, s.timeout)
+	s.record(PriorityNormal, ok)
+	s.sendMu.Unlock()
+
+	if !ok {
+		s.warnDrop(tv.topic, tv.source)
+	}
+	return ok
+}
+
+// PublishRetained publishes v and keeps it as the topic's last value.
+func (t *Topic[T]) PublishRetained(v T, source string) int {
+	return t.bus.publish(Event{Topic: t.name, Payload: v, Source: source}, true)
+}
+
+// Retained returns the topic's retained value, if it holds a T.
+func (t *Topic[T]) Retained() (T, bool) {
+	evt, ok := t.bus.Retained(t.name)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	v, ok := evt.Payload.(T)
+	return v, ok
+}
+
+// Unsubscribe removes the listener and closes C.
+// It is safe to call more than once and from any goroutine.
+func (s *TypedSubscription[T]) Unsubscribe() {
+	s.sub.Unsubscribe()
+}
+
+// Stats returns the counters of the underlying bus subscription.
+func (s *TypedSubscription[T]) Stats() SubscriberStats {
+	return s.sub.Stats()
+}
@@ -0,0 +1,151 @@
+// event/topic_test.go
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func recvTyped[T any](t *testing.T, sub *TypedSubscription[T]) T {
+	t.Helper()
+	select {
+	case v := <-sub.C:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("no typed value received")
+	}
+	panic("unreachable")
+}
+
+func TestTopicSeesAllPublishPaths(t *testing.T) {
+	b := NewBus()
+	level := NewTopicOn[int](b, "light/level")
+	sub := level.Subscribe(context.Background())
+	defer sub.Unsubscribe()
+
+	NewTopicOn[int](b, "light/level").Publish(1, "test")
+	b.Publish("light/level", 0, 2, "test")
+	b.Publish("light/level", 0, "not an int", "test") // skipped
+	b.PublishAfter(time.Millisecond, "light/level", 0, 3, "test")
+
+	for _, want := range []int{1, 2, 3} {
+		if got := recvTyped(t, sub); got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	}
+}
+
+func TestTopicMiddlewareVeto(t *testing.T) {
+	b := NewBus()
+	b.Use(func(evt *Event) error {
+		if evt.Source == "intruder" {
+			return errors.New("denied")
+		}
+		return nil
+	})
+	level := NewTopicOn[int](b, "light/level")
+	sub := level.Subscribe(context.Background())
+	defer sub.Unsubscribe()
+
+	level.Publish(1, "intruder")
+	level.Publish(2, "console")
+
+	if got := recvTyped(t, sub); got != 2 {
+		t.Fatalf("got %d, want only the allowed value 2", got)
+	}
+}
+
+func TestTopicRetainedAndUnsubscribe(t *testing.T) {
+	b := NewBus()
+	level := NewTopicOn[int](b, "light/level")
+	level.PublishRetained(7, "test")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := level.Subscribe(ctx)
+	if got := recvTyped(t, sub); got != 7 {
+		t.Fatalf("late subscriber got %d, want retained 7", got)
+	}
+	if v, ok := level.Retained(); !ok || v != 7 {
+		t.Fatalf("Retained = %d, %v", v, ok)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-sub.C:
+		if ok {
+			t.Fatal("unexpected value after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("C not closed after ctx cancel")
+	}
+}
+
+func TestTopicKeepLatest(t *testing.T) {
+	b := NewBus()
+	level := NewTopicOn[int](b, "light/level")
+	sub := level.Subscribe(context.Background(), WithPolicy(KeepLatest))
+	defer sub.Unsubscribe()
+
+	for i := 1; i <= 5; i++ {
+		level.Publish(i, "test")
+	}
+	if got := recvTyped(t, sub); got != 5 {
+		t.Fatalf("got %d, want the latest value 5", got)
+	}
+
+	// The same holds on the boxed path, with an Event subscriber present.
+	b.Subscribe("light/#")
+	for i := 6; i <= 9; i++ {
+		level.Publish(i, "test")
+	}
+	if got := recvTyped(t, sub); got != 9 {
+		t.Fatalf("got %d, want the latest value 9", got)
+	}
+	if st := sub.Stats(); st.Capacity != 1 || st.Queued != 0 {
+		t.Fatalf("stats = %+v", st)
+	}
+}
+
+type sample struct {
+	Temp, Humidity, Pressure int64
+}
+
+func TestTopicPublishDoesNotAllocate(t *testing.T) {
+	b := NewBus()
+	readings := NewTopicOn[sample](b, "sensors/env")
+	sub := readings.Subscribe(context.Background())
+	defer sub.Unsubscribe()
+
+	// Warm up the bus scratch buffer and topic counters.
+	readings.Publish(sample{}, "test")
+	<-sub.C
+
+	allocs := testing.AllocsPerRun(100, func() {
+		readings.Publish(sample{Temp: 21, Humidity: 40, Pressure: 1013}, "test")
+		<-sub.C
+	})
+	if allocs != 0 {
+		t.Fatalf("Publish allocated %.1f times per call, want 0", allocs)
+	}
+}
+
+func TestTopicUnsubscribeBeforeContext(t *testing.T) {
+	b := NewBus()
+	level := NewTopicOn[int](b, "light/level")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := level.Subscribe(ctx)
+	sub.Unsubscribe()
+	if _, ok := <-sub.C; ok {
+		t.Fatal("C still open after Unsubscribe")
+	}
+	if sub.sub.stop != nil && sub.sub.stop() {
+		t.Fatal("context callback still registered after Unsubscribe")
+	}
+	if level.Publish(1, "test") != 0 || len(b.Stats().Subscribers) != 0 {
+		t.Fatal("subscription still registered after Unsubscribe")
+	}
+}
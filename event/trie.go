@@ -0,0 +1,174 @@
+// event/trie.go
+package event
+
+// Wildcard levels, following MQTT semantics.
+const (
+	// SingleLevel matches exactly one topic level: "sensors/+/temperature".
+	SingleLevel = "+"
+	// MultiLevel matches the parent level and everything below it: "system/#".
+	// It must be the last level of a filter.
+	MultiLevel = "#"
+)
+
+// node is one level of the subscription trie.
+// Children are keyed by the literal level name; wildcards use "+" and "#".
+type node struct {
+	children map[string]*node
+	subs     []*Subscription
+}
+
+// child returns the child for 'level', creating it if needed.
+func (n *node) child(level string) *node {
+	if n.children == nil {
+		n.children = make(map[string]*node, 1)
+	}
+	c, ok := n.children[level]
+	if !ok {
+		c = &node{}
+		n.children[level] = c
+	}
+	return c
+}
+
+// nextLevel splits the first level off 'topic' without allocating.
+// It returns the level, the remainder, and whether a remainder exists.
+func nextLevel(topic string) (level, rest string, more bool) {
+	for i := 0; i < len(topic); i++ {
+		if topic[i] == '/' {
+			return topic[:i], topic[i+1:], true
+		}
+	}
+	return topic, "", false
+}
+
+// ValidFilter reports whether a subscription filter uses wildcards correctly:
+// "+" and "#" must occupy a whole level, and "#" must be the last level.
+func ValidFilter(filter string) bool {
+	rest, more := filter, true
+	for more {
+		var level string
+		level, rest, more = nextLevel(rest)
+		for i := 0; i < len(level); i++ {
+			if (level[i] == '+' || level[i] == '#') && len(level) != 1 {
+				return false
+			}
+		}
+		if level == MultiLevel && more {
+			return false
+		}
+	}
+	return true
+}
+
+// insert walks (and grows) the trie along 'filter' and returns the final node.
+func (n *node) insert(filter string) *node {
+	cur, rest, more := n, filter, true
+	for more {
+		var level string
+		level, rest, more = nextLevel(rest)
+		cur = cur.child(level)
+	}
+	return cur
+}
+
+// remove detaches 'sub' from the node at 'filter' and prunes empty branches.
+func (n *node) remove(filter string, sub *Subscription) {
+	level, rest, more := nextLevel(filter)
+	c, ok := n.children[level]
+	if !ok {
+		return
+	}
+
+	if more {
+		c.remove(rest, sub)
+	} else {
+		for i, s := range c.subs {
+			if s == sub {
+				// Order-preserving delete; the tail slot is cleared for the GC.
+				copy(c.subs[i:], c.subs[i+1:])
+				c.subs[len(c.subs)-1] = nil
+				c.subs = c.subs[:len(c.subs)-1]
+				break
+			}
+		}
+	}
+
+	if len(c.subs) == 0 && len(c.children) == 0 {
+		delete(n.children, level)
+	}
+}
+
+// match appends every subscriber whose filter matches 'topic' to 'out'.
+// The walk is proportional to the topic depth, not to the number of subscriptions.
+func (n *node) match(topic string, out []*Subscription) []*Subscription {
+	if n.children == nil {
+		return out
+	}
+
+	// "#" matches this level and everything below.
+	if c, ok := n.children[MultiLevel]; ok {
+		out = append(out, c.subs...)
+	}
+
+	level, rest, more := nextLevel(topic)
+	out = n.matchChild(level, rest, more, out)
+	if level != SingleLevel {
+		out = n.matchChild(SingleLevel, rest, more, out)
+	}
+	return out
+}
+
+// matchChild continues the walk through the child keyed by 'key'.
+func (n *node) matchChild(key, rest string, more bool, out []*Subscription) []*Subscription {
+	c, ok := n.children[key]
+	if !ok {
+		return out
+	}
+	if more {
+		return c.match(rest, out)
+	}
+
+	// A literal "#" topic level already picked up these subscribers as a wildcard.
+	if key != MultiLevel {
+		out = append(out, c.subs...)
+	}
+	// "a/#" also matches "a" itself.
+	if h, ok := c.children[MultiLevel]; ok {
+		out = append(out, h.subs...)
+	}
+	return out
+}
+
+// walk calls fn for every subscription in the subtree.
+func (n *node) walk(fn func(*Subscription)) {
+	for _, s := range n.subs {
+		fn(s)
+	}
+	for _, c := range n.children {
+		c.walk(fn)
+	}
+}
+
+// Match reports whether a concrete topic matches a subscription filter.
+// It agrees with the subscription trie, also for filters that fail ValidFilter:
+// a "#" that is not the last level is matched literally.
+func Match(filter, topic string) bool {
+	for {
+		fl, frest, fmore := nextLevel(filter)
+		if fl == MultiLevel && !fmore {
+			return true
+		}
+		tl, trest, tmore := nextLevel(topic)
+		if fl != SingleLevel && fl != tl {
+			return false
+		}
+		if !fmore || !tmore {
+			// "a/#" also matches "a".
+			if fmore && !tmore {
+				return frest == MultiLevel
+			}
+			return fmore == tmore
+		}
+		filter, topic = frest, trest
+	}
+}
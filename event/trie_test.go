@@ -0,0 +1,154 @@
+// event/trie_test.go
+package event
+
+import "testing"
+
+var matchTests = []struct {
+	filter, topic string
+	want          bool
+}{
+	{"a/b", "a/b", true},
+	{"a/b", "a/c", false},
+	{"a/b", "a", false},
+	{"a", "a/b", false},
+	{"a//b", "a//b", true},
+	{"a//b", "a/b", false},
+
+	{"+", "a", true},
+	{"+", "", true},
+	{"+", "a/b", false},
+	{"a/+", "a/b", true},
+	{"a/+", "a", false},
+	{"a/+/c", "a/b/c", true},
+	{"a/+/c", "a/b/d", false},
+	{"+/+", "a/b", true},
+
+	{"#", "a", true},
+	{"#", "a/b/c", true},
+	{"#", "", true},
+	{"a/#", "a", true},
+	{"a/#", "a/b/c", true},
+	{"a/#", "b", false},
+	{"a/+/#", "a/b", true},
+	{"a/+/#", "a", false},
+
+	// Topics with literal wildcard levels.
+	{"#", "#", true},
+	{"a/#", "a/#", true},
+	{"+", "+", true},
+	{"a/+", "a/+", true},
+
+	// Malformed filters match their non-terminal wildcards literally.
+	{"a/#/b", "a/x/b", false},
+	{"a/#/b", "a/x", false},
+	{"a/#/b", "a/#/b", true},
+	{"a+", "a+", true},
+	{"a+", "ab", false},
+	{"a/#/#", "a/#", true},
+}
+
+func TestMatch(t *testing.T) {
+	for _, tt := range matchTests {
+		if got := Match(tt.filter, tt.topic); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.filter, tt.topic, got, tt.want)
+		}
+	}
+}
+
+// TestTrieAgreesWithMatch checks that live delivery (the trie) and Match,
+// which drives retained replay and priorities, agree on every case.
+func TestTrieAgreesWithMatch(t *testing.T) {
+	for _, tt := range matchTests {
+		root := &node{}
+		sub := &Subscription{}
+		n := root.insert(tt.filter)
+		n.subs = append(n.subs, sub)
+
+		want := 0
+		if tt.want {
+			want = 1
+		}
+		if got := len(root.match(tt.topic, nil)); got != want {
+			t.Errorf("trie: filter %q, topic %q matched %d times, want %d", tt.filter, tt.topic, got, want)
+		}
+	}
+}
+
+func TestTrieMatchesEachFilterOnce(t *testing.T) {
+	filters := []string{"#", "a/#", "a/+", "+/#", "a/b"}
+	root := &node{}
+	for _, f := range filters {
+		n := root.insert(f)
+		n.subs = append(n.subs, &Subscription{filter: f})
+	}
+
+	for _, topic := range []string{"a/b", "a/#", "a/+", "#"} {
+		seen := map[*Subscription]int{}
+		for _, s := range root.match(topic, nil) {
+			seen[s]++
+		}
+		for s, n := range seen {
+			if n != 1 {
+				t.Errorf("topic %q: filter %q matched %d times", topic, s.filter, n)
+			}
+		}
+		for _, f := range filters {
+			found := false
+			for s := range seen {
+				found = found || s.filter == f
+			}
+			if found != Match(f, topic) {
+				t.Errorf("topic %q: filter %q delivered=%v, Match=%v", topic, f, found, Match(f, topic))
+			}
+		}
+	}
+}
+
+func TestTrieRemovePrunes(t *testing.T) {
+	root := &node{}
+	a, b := &Subscription{}, &Subscription{}
+	root.insert("x/y/z").subs = []*Subscription{a}
+	n := root.insert("x/+")
+	n.subs = append(n.subs, b)
+
+	root.remove("x/y/z", a)
+	if _, ok := root.children["x"].children["y"]; ok {
+		t.Fatal("empty branch x/y was not pruned")
+	}
+	if got := root.match("x/q", nil); len(got) != 1 || got[0] != b {
+		t.Fatalf("x/+ lost after pruning a sibling: %v", got)
+	}
+	root.remove("x/+", b)
+	if len(root.children) != 0 {
+		t.Fatalf("trie not empty after removing every subscription: %v", root.children)
+	}
+}
+
+func TestValidFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"a/b", true},
+		{"a/+/c", true},
+		{"a/#", true},
+		{"#", true},
+		{"a/#/b", false},
+		{"a+", false},
+		{"a/b#", false},
+	}
+	for _, tt := range tests {
+		if got := ValidFilter(tt.filter); got != tt.want {
+			t.Errorf("ValidFilter(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestPublishLiteralWildcardDeliversOnce(t *testing.T) {
+	b := NewBus()
+	sub := b.Subscribe("#")
+	b.Publish("#", 0, nil, "test")
+	if len(sub) != 1 {
+		t.Fatalf("%d events queued for '#', want 1", len(sub))
+	}
+}
@@ -34,4 +34,4 @@ func main() {
 	// 2. Start them in separate Goroutines (calls Start()).
 	// 3. Block forever (calls select{} internally).
 	app.Run()
-}
\ No newline at end of file
+}
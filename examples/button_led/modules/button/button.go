@@ -3,6 +3,7 @@ package button
 
 import (
 	"context"
+	"errors"
 	"machine"
 	"time"
 
@@ -14,58 +15,102 @@ import (
 
 const (
 	ModuleName = "user_button"
-	PollRate   = 50 * time.Millisecond
+	Debounce   = 50 * time.Millisecond
+
+	// EdgeTopic carries raw falling edges from the interrupt handler.
+	EdgeTopic = "user_button/edge"
 )
 
+// Config holds the per-device settings, loaded by the engine before Init.
+type Config struct {
+	Pin      uint8
+	Debounce time.Duration
+}
+
+// Validate rejects settings that would let contact bounce through.
+func (c *Config) Validate() error {
+	if c.Debounce < time.Millisecond {
+		return errors.New("button: debounce must be at least 1ms")
+	}
+	return nil
+}
+
 type ButtonModule struct {
+	cfg Config
 	pin *gpio.Pin
+	src *event.ISRSource
 }
 
 func init() {
-	registry.RegisterModule(&ButtonModule{})
+	registry.RegisterModule(&ButtonModule{
+		cfg: Config{Pin: uint8(machine.GPIO0), Debounce: Debounce},
+	})
+}
+
+// Config exposes the module settings to the engine (gonnect.Configurable).
+func (b *ButtonModule) Config() any {
+	return &b.cfg
 }
 
 func (b *ButtonModule) Init() error {
-	targetPin := machine.GPIO0
-	p, err := gpio.New(targetPin, machine.PinInputPullup, ModuleName)
+	p, err := gpio.New(machine.Pin(b.cfg.Pin), machine.PinInputPullup, ModuleName)
 	if err != nil {
 		return err
 	}
-	b.pin = p
+
+	// The interrupt handler only queues the edge; the bus pump publishes it.
+	// Nothing else here knows when the pin fired, so the pump keeps polling
+	// every event.DefaultPumpInterval while the source is open: a press is
+	// published up to 50ms late, and the CPU wakes 20 times per second even
+	// when idle. Firmware that learns of the edge another way (e.g. a pin
+	// wake from Suspend) can use event.PumpOnDemand and call Flush instead.
+	src := event.NewISRSource(ModuleName, 8, EdgeTopic)
+	if err := p.SetInterrupt(machine.PinFalling, func(machine.Pin) { src.Publish(0, 1) }); err != nil {
+		src.Close()
+		p.Close()
+		return err
+	}
+
+	b.pin, b.src = p, src
 	return nil
 }
 
 func (b *ButtonModule) Start(ctx context.Context) {
-	ticker := time.NewTicker(PollRate)
-	defer ticker.Stop()
+	sub := event.SubscribeContext(ctx, EdgeTopic)
 
-	logger.Info("%s Polling button state...", logger.Tag(ModuleName))
+	logger.Info("%s Waiting for button interrupts...", logger.Tag(ModuleName))
 
-	lastState := true
+	var last int64
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-ticker.C:
-			currentState := b.pin.Get()
-			if lastState && !currentState {
-				logger.Debug("%s Button pressed. Publishing toggle event.", logger.Tag(ModuleName))
-				
-				event.Publish("app/command/toggle", 1, nil, ModuleName)
+		case evt := <-sub.C:
+			// Contact bounce produces bursts of edges; keep the first one.
+			if evt.Timestamp-last < int64(b.cfg.Debounce) {
+				continue
 			}
-			lastState = currentState
+			last = evt.Timestamp
+
+			logger.Debug("%s Button pressed. Publishing toggle event.", logger.Tag(ModuleName))
+			event.Publish("app/command/toggle", 1, nil, ModuleName)
 		}
 	}
 }
 
 func (b *ButtonModule) Stop() error {
+	var err error
 	if b.pin != nil {
-		return b.pin.Close()
+		// Closing the pin removes the interrupt handler before the source goes away.
+		err = b.pin.Close()
 	}
-	return nil
+	if b.src != nil {
+		b.src.Close()
+	}
+	return err
 }
 
 func (b *ButtonModule) Name() string {
 	return ModuleName
-}
\ No newline at end of file
+}
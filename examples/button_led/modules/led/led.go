@@ -13,17 +13,29 @@ import (
 
 const ModuleName = "status_led"
 
+// Config holds the per-device settings, loaded by the engine before Init.
+type Config struct {
+	Pin uint8
+}
+
 type LedModule struct {
+	cfg Config
 	pin *gpio.Pin
 }
 
 func init() {
-	registry.RegisterModule(&LedModule{})
+	registry.RegisterModule(&LedModule{
+		cfg: Config{Pin: uint8(machine.GPIO13)},
+	})
+}
+
+// Config exposes the module settings to the engine (gonnect.Configurable).
+func (l *LedModule) Config() any {
+	return &l.cfg
 }
 
 func (l *LedModule) Init() error {
-	targetPin := machine.GPIO13 
-	p, err := gpio.New(targetPin, machine.PinOutput, ModuleName)
+	p, err := gpio.New(machine.Pin(l.cfg.Pin), machine.PinOutput, ModuleName)
 	if err != nil {
 		return err
 	}
@@ -32,8 +44,9 @@ func (l *LedModule) Init() error {
 }
 
 func (l *LedModule) Start(ctx context.Context) {
-	events := event.Subscribe("app/command/toggle")
-	
+	// The subscription is removed when ctx is cancelled (stop or restart).
+	sub := event.SubscribeContext(ctx, "app/command/toggle")
+
 	// ცვლილება: ვიყენებთ logger.Tag()-ს ფერისთვის
 	logger.Info("%s Listening for toggle events...", logger.Tag(ModuleName))
 
@@ -41,10 +54,10 @@ func (l *LedModule) Start(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case evt := <-events:
-			logger.Debug("%s Toggle signal received (Source: %s)", 
+		case evt := <-sub.C:
+			logger.Debug("%s Toggle signal received (Source: %s)",
 				logger.Tag(ModuleName), evt.Source)
-			
+
 			l.pin.Toggle()
 		}
 	}
@@ -60,4 +73,4 @@ func (l *LedModule) Stop() error {
 
 func (l *LedModule) Name() string {
 	return ModuleName
-}
\ No newline at end of file
+}
@@ -21,4 +21,4 @@ func main() {
 	// Start Engine
 	app := engine.New(nil)
 	app.Run()
-}
\ No newline at end of file
+}
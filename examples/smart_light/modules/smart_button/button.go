@@ -14,7 +14,7 @@ import (
 const (
 	ModuleName = "smart_button"
 	Topic      = "input/command"
-	
+
 	// Constants for timing logic
 	DebounceTime  = 50 * time.Millisecond
 	DoubleGap     = 300 * time.Millisecond // Max time between clicks for double click
@@ -28,17 +28,30 @@ const (
 	CmdLongPress   = 3
 )
 
+// Config holds the per-device settings, loaded by the engine before Init.
+type Config struct {
+	Pin uint8
+}
+
 type SmartButton struct {
+	cfg Config
 	pin *gpio.Pin
 }
 
 func init() {
-	registry.RegisterModule(&SmartButton{})
+	registry.RegisterModule(&SmartButton{
+		// Boot button (GPIO 0 on ESP32/Pico generally, or check your board)
+		cfg: Config{Pin: uint8(machine.GPIO0)},
+	})
+}
+
+// Config exposes the module settings to the engine (gonnect.Configurable).
+func (b *SmartButton) Config() any {
+	return &b.cfg
 }
 
 func (b *SmartButton) Init() error {
-	// Boot button (GPIO 0 on ESP32/Pico generally, or check your board)
-	p, err := gpio.New(machine.GPIO0, machine.PinInputPullup, ModuleName)
+	p, err := gpio.New(machine.Pin(b.cfg.Pin), machine.PinInputPullup, ModuleName)
 	if err != nil {
 		return err
 	}
@@ -54,11 +67,11 @@ func (b *SmartButton) Start(ctx context.Context) {
 
 	// State variables
 	var (
-		isPressed      bool
-		pressStartTime time.Time
+		isPressed       bool
+		pressStartTime  time.Time
 		lastReleaseTime time.Time
-		clickCount     int
-		longPressSent  bool
+		clickCount      int
+		longPressSent   bool
 	)
 
 	for {
@@ -86,14 +99,14 @@ func (b *SmartButton) Start(ctx context.Context) {
 					event.Publish(Topic, CmdLongPress, nil, ModuleName)
 					longPressSent = true
 					// Reset click count to avoid confusion on release
-					clickCount = 0 
+					clickCount = 0
 				}
 			}
 
 			// --- Logic: Button Just Released ---
 			if !currentPressed && isPressed {
 				isPressed = false
-				
+
 				// Ignore release if it was a long press
 				if !longPressSent {
 					clickCount++
@@ -131,4 +144,4 @@ func (b *SmartButton) Stop() error {
 
 func (b *SmartButton) Name() string {
 	return ModuleName
-}
\ No newline at end of file
+}
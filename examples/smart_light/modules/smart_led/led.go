@@ -14,6 +14,10 @@ import (
 const (
 	ModuleName = "smart_led"
 	Topic      = "input/command"
+
+	// FrameTopic drives the animation, published by the bus scheduler.
+	FrameTopic    = "smart_led/frame"
+	FrameInterval = 50 * time.Millisecond
 )
 
 // Modes
@@ -23,32 +27,51 @@ const (
 	ModeHeartbeat = 2 // Pulse effect - from Double Click
 )
 
+// Config holds the per-device settings, loaded by the engine before Init.
+type Config struct {
+	Pin uint8
+}
+
 type SmartLed struct {
+	cfg  Config
 	pin  *gpio.Pin
 	mode int
 }
 
 func init() {
-	registry.RegisterModule(&SmartLed{})
+	registry.RegisterModule(&SmartLed{
+		cfg: Config{Pin: uint8(machine.GPIO13)},
+	})
+}
+
+// Config exposes the module settings to the engine (gonnect.Configurable).
+func (l *SmartLed) Config() any {
+	return &l.cfg
 }
 
 func (l *SmartLed) Init() error {
-    targetPin := machine.GPIO13 
-
-    p, err := gpio.New(targetPin, machine.PinOutput, ModuleName)
-    if err != nil {
-        return err
-    }
-    l.pin = p
-    return nil
+	p, err := gpio.New(machine.Pin(l.cfg.Pin), machine.PinOutput, ModuleName)
+	if err != nil {
+		return err
+	}
+	l.pin = p
+	return nil
 }
 
 func (l *SmartLed) Start(ctx context.Context) {
-	events := event.Subscribe(Topic)
-	
-	// Base ticker for animation frames (50ms resolution)
-	ticker := time.NewTicker(50 * time.Millisecond)
-	defer ticker.Stop()
+	// The subscription is removed when ctx is cancelled (stop or restart).
+	sub := event.SubscribeContext(ctx, Topic)
+	// Only the newest frame matters; a late frame is not worth replaying.
+	frames := event.SubscribeContext(ctx, FrameTopic, event.WithPolicy(event.KeepLatest))
+
+	// Animation frames (50ms resolution) come from the shared bus timer,
+	// which only runs while an animated mode is active.
+	var anim *event.Timer
+	defer func() {
+		if anim != nil {
+			anim.Stop()
+		}
+	}()
 
 	logger.Info("%s Listening...", logger.Tag(ModuleName))
 
@@ -61,7 +84,7 @@ func (l *SmartLed) Start(ctx context.Context) {
 			return
 
 		// --- Event Handling ---
-		case evt := <-events:
+		case evt := <-sub.C:
 			cmd := evt.Value
 			switch cmd {
 			case 1: // Single Click -> OFF
@@ -78,16 +101,20 @@ func (l *SmartLed) Start(ctx context.Context) {
 			// Reset animation counter on mode change
 			tickCount = 0
 
+			if l.mode == ModeOff {
+				if anim != nil {
+					anim.Stop()
+					anim = nil
+				}
+			} else if anim == nil {
+				anim = event.PublishEvery(FrameInterval, FrameTopic, 0, nil, ModuleName)
+			}
+
 		// --- Animation Loop ---
-		case <-ticker.C:
+		case <-frames.C:
 			tickCount++
-			
-			switch l.mode {
-			case ModeOff:
-				// Ensure it stays off
-				// (Optional: optimize by checking current state)
-				l.pin.Low()
 
+			switch l.mode {
 			case ModeStrobe:
 				// Fast blink: Toggle every 100ms (2 ticks)
 				if tickCount%2 == 0 {
@@ -121,4 +148,4 @@ func (l *SmartLed) Stop() error {
 
 func (l *SmartLed) Name() string {
 	return ModuleName
-}
\ No newline at end of file
+}
@@ -1,7 +1,10 @@
 // gonnect.go
 package gonnect
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Service is a semantic alias for any type registered in the Service Locator.
 // It serves as a marker to indicate that a struct is intended to be shared.
@@ -11,7 +14,8 @@ type Service = any
 type Module interface {
 	// Init configures the module and claims necessary resources.
 	// It is called synchronously on the main thread during boot.
-	// Critical Setup: Failure here (returning error) halts the system immediately.
+	// Critical Setup: Failure here (returning error) halts the system immediately,
+	// unless the module is marked optional via Criticality.
 	Init() error
 
 	// Start executes the module's main logic.
@@ -28,4 +32,44 @@ type Module interface {
 	// Name returns the unique identifier for logging and registration.
 	// Ideally, return a string constant to avoid heap allocation.
 	Name() string
-}
\ No newline at end of file
+}
+
+// Dependent is an optional interface for modules that rely on other modules.
+// The Engine initializes and starts every dependency before the dependent module,
+// and stops them in reverse order. This removes the reliance on import order in main.go.
+type Dependent interface {
+	// DependsOn returns the names of the modules that must be booted first.
+	DependsOn() []string
+}
+
+// Criticality is an optional interface that lets a module declare whether the system
+// can run without it. Modules that do not implement it are treated as critical.
+//
+// If an optional module fails Init, the Engine releases its resources, skips it
+// (and any module depending on it) and boots the rest of the system in degraded mode.
+type Criticality interface {
+	Critical() bool
+}
+
+// ShutdownTimeout is an optional interface for modules that need a different
+// shutdown budget than the Engine default (e.g., flushing a slow flash log).
+// The budget bounds both waiting for Start to return and the Stop call.
+type ShutdownTimeout interface {
+	ShutdownTimeout() time.Duration
+}
+
+// Watched is an optional interface for modules supervised by the Engine's watchdog.
+// While running, the module must call engine.Heartbeat(ctx) at least once per interval;
+// otherwise the Engine stops feeding the hardware watchdog and the device resets.
+type Watched interface {
+	HeartbeatInterval() time.Duration
+}
+
+// Configurable is an optional interface for modules with per-device settings
+// (pins, timings). Config returns a pointer to the module's config struct,
+// pre-filled with defaults. Before Init, the Engine loads the section named after
+// the module from its config.Manager into that struct and validates it
+// (config.Validator), so Init can rely on the values.
+type Configurable interface {
+	Config() any
+}
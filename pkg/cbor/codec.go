@@ -2,6 +2,8 @@
 package cbor
 
 import (
+	"io"
+
 	"github.com/fxamacker/cbor/v2"
 )
 
@@ -34,4 +36,30 @@ func Marshal(v interface{}) ([]byte, error) {
 // 'v' must be a non-nil pointer.
 func Unmarshal(data []byte, v interface{}) error {
 	return cbor.Unmarshal(data, v)
-}
\ No newline at end of file
+}
+
+// RawMessage is a raw encoded CBOR value. It can be used to delay decoding,
+// e.g. when a blob holds independently typed sections.
+type RawMessage = cbor.RawMessage
+
+// Encoder writes a stream of CBOR values using the canonical options.
+type Encoder = cbor.Encoder
+
+// Decoder reads a stream of CBOR values.
+type Decoder = cbor.Decoder
+
+// NewEncoder returns an encoder writing to w, e.g. for append-only logs.
+func NewEncoder(w io.Writer) *Encoder {
+	return encMode.NewEncoder(w)
+}
+
+// NewDecoder returns a decoder reading from r. Decode returns io.EOF at the end of the stream.
+func NewDecoder(r io.Reader) *Decoder {
+	return cbor.NewDecoder(r)
+}
+
+// Tag is a CBOR tagged value, used to mark the format of a stored blob.
+type Tag = cbor.Tag
+
+// RawTag is a CBOR tag with undecoded content.
+type RawTag = cbor.RawTag
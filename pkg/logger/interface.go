@@ -50,6 +50,21 @@ func SetLevel(level LogLevel) {
 	globalLogger.SetLevel(level)
 }
 
+// Default returns a Logger that forwards to the current global logger.
+// It follows later SetLogger/SetLevel calls, so it is safe to capture early.
+func Default() Logger {
+	return globalProxy{}
+}
+
+// globalProxy implements Logger on top of the package-level functions.
+type globalProxy struct{}
+
+func (globalProxy) Debug(msg string, args ...any) { globalLogger.Debug(msg, args...) }
+func (globalProxy) Info(msg string, args ...any)  { globalLogger.Info(msg, args...) }
+func (globalProxy) Warn(msg string, args ...any)  { globalLogger.Warn(msg, args...) }
+func (globalProxy) Error(msg string, args ...any) { globalLogger.Error(msg, args...) }
+func (globalProxy) SetLevel(level LogLevel)       { globalLogger.SetLevel(level) }
+
 // Global accessor functions
 func Debug(msg string, args ...any) { globalLogger.Debug(msg, args...) }
 func Info(msg string, args ...any)  { globalLogger.Info(msg, args...) }
@@ -82,14 +97,14 @@ func (l *StandardLogger) print(reqLevel LogLevel, color, label, msg string, args
 	now := time.Now()
 	// UnixNano is supported efficiently on most TinyGo targets
 	nanos := now.UnixNano()
-	
+
 	sec := nanos / 1e9
 	ms := (nanos % 1e9) / 1e6
 
 	// Format: SSS.mmm [LEVEL] MESSAGE
 	// We construct the prefix manually and let fmt handle the user args.
 	prefix := fmt.Sprintf("%d.%03d %s[%s]%s ", sec, ms, color, label, Reset)
-	
+
 	// Print in one go. Using \r\n for serial terminal compatibility.
 	fmt.Printf(prefix+msg+"\r\n", args...)
 }
@@ -108,4 +123,4 @@ func (l *StandardLogger) Warn(msg string, args ...any) {
 
 func (l *StandardLogger) Error(msg string, args ...any) {
 	l.print(LevelError, Red, "ERROR", msg, args...)
-}
\ No newline at end of file
+}
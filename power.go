@@ -0,0 +1,54 @@
+// power.go
+package gonnect
+
+import "strconv"
+
+// WakeCause tells resumed modules why the system left low-power mode.
+type WakeCause uint8
+
+const (
+	// WakeUnknown is reported when the sleep backend cannot tell (or on a cold boot).
+	WakeUnknown WakeCause = iota
+	// WakeTimer means the requested sleep duration elapsed.
+	WakeTimer
+	// WakePin means an external pin (button, sensor interrupt) woke the device.
+	WakePin
+	// WakeExternal means another wake source (UART, RTC alarm, radio) fired.
+	WakeExternal
+	// WakeAborted means the suspend was rolled back before the device slept.
+	WakeAborted
+	// wakeLimit is used for boundary checking in the String method.
+	wakeLimit
+)
+
+var wakeNames = [...]string{
+	"unknown",
+	"timer",
+	"pin",
+	"external",
+	"aborted",
+}
+
+// String returns the lowercase name of the wake cause.
+func (c WakeCause) String() string {
+	if c < wakeLimit {
+		return wakeNames[c]
+	}
+	return "unknown(" + strconv.Itoa(int(c)) + ")"
+}
+
+// Suspender is an optional interface for modules that must prepare hardware
+// for low-power mode (e.g., power down a sensor) and restore it afterwards.
+// Suspend is called after the module's Start has returned; Start is launched
+// again after Resume.
+type Suspender interface {
+	Suspend() error
+	Resume(cause WakeCause) error
+}
+
+// Persistent is an optional interface for modules whose state must survive
+// a sleep cycle that resets the CPU. State returns a pointer to a CBOR-encodable
+// struct; the Engine saves it before sleeping and loads it back before Init.
+type Persistent interface {
+	State() any
+}
@@ -16,9 +16,6 @@ var (
 	ErrTypeMismatch = errors.New("registry: service type mismatch")
 )
 
-// defaultLocator is the singleton instance.
-var defaultLocator = &locator{}
-
 type locator struct {
 	// We use sync.Mutex instead of RWMutex.
 	// On single-core MCUs, RWMutex adds binary bloat with no parallel performance benefit.
@@ -33,55 +30,79 @@ func (l *locator) ensureInit() {
 	}
 }
 
-// RegisterService adds a service implementation to the registry.
+// RegisterService adds a service implementation to the default registry.
 // Returns an error if the name is already taken.
 func RegisterService(name string, service interface{}) error {
-	defaultLocator.mu.Lock()
-	defer defaultLocator.mu.Unlock()
+	return defaultRegistry.RegisterService(name, service)
+}
+
+// UnregisterService removes a service from the default registry. Safe to call if not found.
+func UnregisterService(name string) {
+	defaultRegistry.UnregisterService(name)
+}
+
+// GetServiceTyped retrieves a strongly-typed instance of a service from the default registry.
+// T is the expected interface or struct type.
+//
+// Usage:
+//
+//	mqtt, err := registry.GetServiceTyped[MQTTClient]("mqtt_main")
+func GetServiceTyped[T any](name string) (T, error) {
+	return GetServiceTypedFrom[T](defaultRegistry, name)
+}
+
+// RegisterService adds a service implementation to this registry.
+// Returns an error if the name is already taken.
+func (r *Registry) RegisterService(name string, service interface{}) error {
+	l := &r.services
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
-	defaultLocator.ensureInit()
+	l.ensureInit()
 
-	if _, exists := defaultLocator.services[name]; exists {
+	if _, exists := l.services[name]; exists {
 		return fmt.Errorf("registry: service '%s' already exists", name)
 	}
 
-	defaultLocator.services[name] = service
+	l.services[name] = service
 	logger.Debug("Service registered: '%s'", name)
 	return nil
 }
 
 // UnregisterService removes a service. Safe to call if not found.
-func UnregisterService(name string) {
-	defaultLocator.mu.Lock()
-	defer defaultLocator.mu.Unlock()
+func (r *Registry) UnregisterService(name string) {
+	l := &r.services
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
-	if defaultLocator.services == nil {
+	if l.services == nil {
 		return
 	}
 
-	if _, exists := defaultLocator.services[name]; exists {
-		delete(defaultLocator.services, name)
+	if _, exists := l.services[name]; exists {
+		delete(l.services, name)
 		logger.Debug("Service unregistered: '%s'", name)
 	}
 }
 
-// GetServiceTyped retrieves a strongly-typed instance of a service.
-// T is the expected interface or struct type.
+// GetServiceTypedFrom retrieves a strongly-typed service from a specific registry.
+// Go does not allow type parameters on methods, hence the free function.
 //
 // Usage:
 //
-//	mqtt, err := registry.GetServiceTyped[MQTTClient]("mqtt_main")
-func GetServiceTyped[T any](name string) (T, error) {
-	defaultLocator.mu.Lock()
-	defer defaultLocator.mu.Unlock()
+//	mqtt, err := registry.GetServiceTypedFrom[MQTTClient](rt.Registry, "mqtt_main")
+func GetServiceTypedFrom[T any](r *Registry, name string) (T, error) {
+	l := &r.services
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
 	var zero T
 
-	if defaultLocator.services == nil {
+	if l.services == nil {
 		return zero, ErrServiceNotFound
 	}
 
-	raw, exists := defaultLocator.services[name]
+	raw, exists := l.services[name]
 	if !exists {
 		return zero, ErrServiceNotFound
 	}
@@ -95,4 +116,4 @@ func GetServiceTyped[T any](name string) (T, error) {
 	}
 
 	return typed, nil
-}
\ No newline at end of file
+}
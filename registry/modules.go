@@ -3,26 +3,29 @@ package registry
 
 import (
 	"fmt"
-	"sync"
 
 	"github.com/magradze/gonnect"
 	"github.com/magradze/gonnect/pkg/logger"
 )
 
-var (
-	modulesMu sync.Mutex
-	// modules holds the list of registered components.
-	// We use a Slice instead of a Map to preserve initialization order (Deterministic Startup).
-	// The order is determined by the import order in main.go.
-	modules []gonnect.Module
-)
-
-// RegisterModule adds a new module to the system lifecycle.
+// RegisterModule adds a new module to the default registry.
 // This is typically called within the init() function of the module package.
 // It panics if a module with the same name is already registered.
 func RegisterModule(m gonnect.Module) {
-	modulesMu.Lock()
-	defer modulesMu.Unlock()
+	defaultRegistry.RegisterModule(m)
+}
+
+// GetModules returns the slice of modules in the default registry.
+// The Engine uses this list to boot components in the order they were imported.
+func GetModules() []gonnect.Module {
+	return defaultRegistry.GetModules()
+}
+
+// RegisterModule adds a new module to this registry's lifecycle.
+// It panics if a module with the same name is already registered.
+func (r *Registry) RegisterModule(m gonnect.Module) {
+	r.modulesMu.Lock()
+	defer r.modulesMu.Unlock()
 
 	name := m.Name()
 	if name == "" {
@@ -32,20 +35,19 @@ func RegisterModule(m gonnect.Module) {
 	// Linear scan for duplicates (O(N)).
 	// Since N (module count) is small in embedded systems (<50),
 	// this is faster and lighter on RAM than a Map hash lookup.
-	for _, existing := range modules {
+	for _, existing := range r.modules {
 		if existing.Name() == name {
 			panic(fmt.Sprintf("gonnect: module '%s' is already registered", name))
 		}
 	}
 
-	modules = append(modules, m)
+	r.modules = append(r.modules, m)
 	logger.Debug("Module registered: '%s'", name)
 }
 
-// GetModules returns the slice of registered modules.
-// The Engine uses this list to boot components in the order they were imported.
-func GetModules() []gonnect.Module {
-	modulesMu.Lock()
-	defer modulesMu.Unlock()
-	return modules
-}
\ No newline at end of file
+// GetModules returns the slice of registered modules in registration order.
+func (r *Registry) GetModules() []gonnect.Module {
+	r.modulesMu.Lock()
+	defer r.modulesMu.Unlock()
+	return r.modules
+}
@@ -0,0 +1,34 @@
+// registry/registry.go
+package registry
+
+import (
+	"sync"
+
+	"github.com/magradze/gonnect"
+)
+
+// Registry holds the modules and services of one Engine instance.
+// Package-level functions operate on a default instance, which is what
+// modules registering themselves from init() use.
+type Registry struct {
+	modulesMu sync.Mutex
+	// modules holds the list of registered components.
+	// We use a Slice instead of a Map to preserve initialization order (Deterministic Startup).
+	// The order is determined by the import order in main.go.
+	modules []gonnect.Module
+
+	services locator
+}
+
+// defaultRegistry is the process-wide instance used by the package-level functions.
+var defaultRegistry = &Registry{}
+
+// New creates an empty, isolated registry.
+func New() *Registry {
+	return &Registry{}
+}
+
+// Default returns the process-wide registry.
+func Default() *Registry {
+	return defaultRegistry
+}
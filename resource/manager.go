@@ -2,6 +2,7 @@
 package resource
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
@@ -17,16 +18,26 @@ type resourceKey struct {
 
 // Manager handles the atomic allocation and locking of hardware resources.
 type Manager struct {
-	mu    sync.Mutex
+	mu sync.Mutex
 	// locks stores the owner of each resource.
 	// We use a flat map with a struct key to reduce heap allocations and GC scan time.
 	locks map[resourceKey]string
 }
 
-// globalManager is the singleton instance.
+// globalManager is the process-wide instance used by the package-level functions.
 // The map is lazily initialized to save memory if no resources are ever locked.
 var globalManager = &Manager{}
 
+// NewManager creates an isolated resource manager, e.g. for a second Engine or a test.
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Default returns the process-wide resource manager.
+func Default() *Manager {
+	return globalManager
+}
+
 // ensureInit initializes the map if it hasn't been created yet.
 // This allows the binary to start with zero heap allocation for the manager.
 func (m *Manager) ensureInit() {
@@ -37,25 +48,25 @@ func (m *Manager) ensureInit() {
 
 // Lock claims exclusive access to a hardware resource.
 // It returns an error if the resource is already owned by another component.
-func Lock(t Type, id ID, owner string) error {
-	globalManager.mu.Lock()
-	defer globalManager.mu.Unlock()
+func (m *Manager) Lock(t Type, id ID, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	globalManager.ensureInit()
+	m.ensureInit()
 
 	key := resourceKey{Type: t, ID: id}
 
 	// Fast path: Check for existence
-	if currentOwner, exists := globalManager.locks[key]; exists {
+	if currentOwner, exists := m.locks[key]; exists {
 		// Error construction is deferred until failure to avoid allocation on the happy path.
 		errMsg := fmt.Sprintf("resource conflict: %s/%d owned by '%s', requested by '%s'",
 			t, id, currentOwner, owner)
 		logger.Error(errMsg)
-		return fmt.Errorf(errMsg)
+		return errors.New(errMsg)
 	}
 
 	// Success
-	globalManager.locks[key] = owner
+	m.locks[key] = owner
 	logger.Debug("Resource locked: %s/%d by '%s'", t, id, owner)
 
 	return nil
@@ -63,17 +74,17 @@ func Lock(t Type, id ID, owner string) error {
 
 // Unlock releases a resource.
 // It enforces strict ownership validation to prevent unauthorized release.
-func Unlock(t Type, id ID, owner string) error {
-	globalManager.mu.Lock()
-	defer globalManager.mu.Unlock()
+func (m *Manager) Unlock(t Type, id ID, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	if globalManager.locks == nil {
+	if m.locks == nil {
 		return fmt.Errorf("resource manager: no locks active")
 	}
 
 	key := resourceKey{Type: t, ID: id}
 
-	currentOwner, exists := globalManager.locks[key]
+	currentOwner, exists := m.locks[key]
 	if !exists {
 		return fmt.Errorf("resource unlock failed: %s/%d is not locked", t, id)
 	}
@@ -82,41 +93,84 @@ func Unlock(t Type, id ID, owner string) error {
 		errMsg := fmt.Sprintf("security violation: '%s' tried to unlock %s/%d owned by '%s'",
 			owner, t, id, currentOwner)
 		logger.Warn(errMsg)
-		return fmt.Errorf(errMsg)
+		return errors.New(errMsg)
 	}
 
 	// Delete removes the key from the map.
 	// Note: In Go maps, this does not shrink the memory footprint immediately,
 	// but marks the slot as empty for reuse.
-	delete(globalManager.locks, key)
+	delete(m.locks, key)
 	logger.Debug("Resource unlocked: %s/%d by '%s'", t, id, owner)
 
 	return nil
 }
 
 // IsLocked checks if a resource is currently busy.
-func IsLocked(t Type, id ID) bool {
-	globalManager.mu.Lock()
-	defer globalManager.mu.Unlock()
+func (m *Manager) IsLocked(t Type, id ID) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	if globalManager.locks == nil {
+	if m.locks == nil {
 		return false
 	}
 
 	key := resourceKey{Type: t, ID: id}
-	_, exists := globalManager.locks[key]
+	_, exists := m.locks[key]
 	return exists
 }
 
 // GetOwner returns the owner name of a resource or empty string.
-func GetOwner(t Type, id ID) string {
-	globalManager.mu.Lock()
-	defer globalManager.mu.Unlock()
+func (m *Manager) GetOwner(t Type, id ID) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	if globalManager.locks == nil {
+	if m.locks == nil {
 		return ""
 	}
 
 	key := resourceKey{Type: t, ID: id}
-	return globalManager.locks[key]
-}
\ No newline at end of file
+	return m.locks[key]
+}
+
+// ReleaseAll frees every resource held by 'owner' and returns how many were released.
+// The Engine uses this to reclaim hardware from modules that failed or were stopped,
+// relying on the convention that modules lock resources under their own Name().
+func (m *Manager) ReleaseAll(owner string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	released := 0
+	for key, current := range m.locks {
+		if current == owner {
+			delete(m.locks, key)
+			logger.Debug("Resource released: %s/%d from '%s'", key.Type, key.ID, owner)
+			released++
+		}
+	}
+	return released
+}
+
+// Lock claims exclusive access to a hardware resource on the default manager.
+func Lock(t Type, id ID, owner string) error {
+	return globalManager.Lock(t, id, owner)
+}
+
+// Unlock releases a resource on the default manager.
+func Unlock(t Type, id ID, owner string) error {
+	return globalManager.Unlock(t, id, owner)
+}
+
+// IsLocked checks if a resource is currently busy on the default manager.
+func IsLocked(t Type, id ID) bool {
+	return globalManager.IsLocked(t, id)
+}
+
+// GetOwner returns the owner name of a resource on the default manager.
+func GetOwner(t Type, id ID) string {
+	return globalManager.GetOwner(t, id)
+}
+
+// ReleaseAll frees every resource held by 'owner' on the default manager.
+func ReleaseAll(owner string) int {
+	return globalManager.ReleaseAll(owner)
+}
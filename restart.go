@@ -0,0 +1,53 @@
+// restart.go
+package gonnect
+
+import "time"
+
+// RestartMode selects when the Engine relaunches a module whose Start returned.
+type RestartMode uint8
+
+const (
+	// RestartNever leaves the module stopped once Start returns (default).
+	RestartNever RestartMode = iota
+	// RestartOnPanic relaunches Start only if it terminated with a panic.
+	RestartOnPanic
+	// RestartAlways relaunches Start whenever it returns before shutdown.
+	RestartAlways
+)
+
+// Escalation selects what the Engine does when a module exhausts its restart budget.
+type Escalation uint8
+
+const (
+	// EscalateNone leaves the module dead while the rest of the system keeps running.
+	EscalateNone Escalation = iota
+	// EscalateShutdown stops the whole Engine.
+	EscalateShutdown
+	// EscalateReboot invokes the Engine's RebootHook (falls back to shutdown if unset).
+	EscalateReboot
+)
+
+// RestartPolicy describes how the Engine supervises a module's Start goroutine.
+// The zero value disables restarts, which matches the behaviour of unsupervised modules.
+type RestartPolicy struct {
+	Mode RestartMode
+
+	// InitialBackoff is the delay before the first restart. It doubles on each
+	// consecutive restart, capped at MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// MaxRestarts is the budget of restarts allowed within Window.
+	// Zero or negative means unlimited. A zero Window counts restarts over the module lifetime.
+	MaxRestarts int
+	Window      time.Duration
+
+	// Escalation is applied once the budget is exceeded.
+	Escalation Escalation
+}
+
+// Supervised is an optional interface for modules that want to be restarted
+// after a crash instead of staying dead until the next reboot.
+type Supervised interface {
+	RestartPolicy() RestartPolicy
+}